@@ -1,7 +1,13 @@
 package hubspot
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 )
 
 // Associate associates HubSpot objects like Deal and Contact.
@@ -11,16 +17,48 @@ import (
 
 const (
 	associationBasePath = "associations"
+
+	// associationAPIVersion is the version of the associations API, which differs from the version of the object APIs.
+	associationAPIVersion = "v4"
+
+	// associationReadPageLimit is the maximum number of associations HubSpot returns in a page.
+	associationReadPageLimit = 500
+
+	// associationTypesTTL is how long the association types of a pair of object types are cached by ListAssociationTypes.
+	associationTypesTTL = 10 * time.Minute
 )
 
+// AssociationService is an interface of the v4 association endpoints of the HubSpot API.
+// Reference: https://developers.hubspot.com/docs/api/crm/associations
+type AssociationService interface {
+	GetAll(fromType ObjectType, fromID string, toType ObjectType) ([]*LabeledAssociation, error)
+	GetAllOfTypes(fromType ObjectType, fromID string, toType ObjectType, typeIDs []int) ([]*LabeledAssociation, error)
+	CreateBatch(fromType, toType ObjectType, pairs []AssociationPair) error
+	ListAssociationTypes(fromType, toType ObjectType) ([]*AssociationLabel, error)
+}
+
+// AssociationServiceOp handles communication with the association related methods of the HubSpot API.
+type AssociationServiceOp struct {
+	associationPath string
+	// objectPath is the path of the v4 object endpoints, under which the associations of an object are read.
+	objectPath string
+	client     *Client
+	// associationTypes caches the association types got by ListAssociationTypes per pair of object types.
+	associationTypes sync.Map // map[[2]ObjectType]*associationTypesEntry
+}
+
+var _ AssociationService = (*AssociationServiceOp)(nil)
+
 // ObjectType is the name used in object association.
 type ObjectType string
 
 // Default Object types
 const (
-	ObjectTypeCompany ObjectType = "companies"
-	ObjectTypeContact ObjectType = "contacts"
-	ObjectTypeDeal    ObjectType = "deals"
+	ObjectTypeCompany  ObjectType = "companies"
+	ObjectTypeContact  ObjectType = "contacts"
+	ObjectTypeDeal     ObjectType = "deals"
+	ObjectTypeQuote    ObjectType = "quotes"
+	ObjectTypeLineItem ObjectType = "line_items"
 )
 
 // AssociationType is the name of the key used to associate the objects together.
@@ -42,6 +80,9 @@ const (
 
 	AssociationTypeCompanyToContact AssociationType = "company_to_contact"
 	AssociationTypeCompanyToDeal    AssociationType = "company_to_deal"
+
+	AssociationTypeQuoteToDeal     AssociationType = "quote_to_deal"
+	AssociationTypeQuoteToLineItem AssociationType = "quote_to_line_item"
 )
 
 type AssociationConfig struct {
@@ -54,19 +95,337 @@ func (c *AssociationConfig) makeAssociationPath() string {
 	return fmt.Sprintf("%s/%s/%s/%s", associationBasePath, c.ToObject, c.ToObjectID, c.Type)
 }
 
-type Associations struct {
-	Companies struct {
-		Results []AssociationResult `json:"results"`
-	} `json:"companies"`
-	Contacts struct {
-		Results []AssociationResult `json:"results"`
-	} `json:"contacts"`
-	Deals struct {
-		Results []AssociationResult `json:"results"`
-	} `json:"deals"`
+// Associations are the associated objects returned inline for RequestQueryOption.Associations, keyed by the object type
+// as requested, e.g. resource.Associations["contacts"]. Any object type can be requested, including custom objects.
+type Associations map[string]AssociationList
+
+// IDs returns the IDs of the associated objects of the type, or nil if there is none.
+func (a Associations) IDs(objectType string) []string {
+	var ids []string
+	for _, r := range a[objectType].Results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// AssociationList is the associated objects of a type.
+// HubSpot returns only the first page inline, and Paging is set if there are more.
+// The object services follow the pages, so Results are complete and Paging is nil once returned by their Get.
+type AssociationList struct {
+	Results []AssociationResult `json:"results"`
+	Paging  *Paging             `json:"paging,omitempty"`
 }
 
 type AssociationResult struct {
 	ID   string `json:"id"`
 	Type string `json:"type"`
 }
+
+// AssociationCategory is the category of an association type.
+type AssociationCategory string
+
+// Association categories
+const (
+	AssociationCategoryHubSpotDefined    AssociationCategory = "HUBSPOT_DEFINED"
+	AssociationCategoryUserDefined       AssociationCategory = "USER_DEFINED"
+	AssociationCategoryIntegratorDefined AssociationCategory = "INTEGRATOR_DEFINED"
+)
+
+// Default association type IDs used when associating on create.
+// Reference: https://developers.hubspot.com/docs/api/crm/associations
+const (
+	AssociationTypeIDEmailToContact = 198
+	AssociationTypeIDEmailToCompany = 186
+	AssociationTypeIDEmailToDeal    = 210
+
+	AssociationTypeIDMeetingToContact = 200
+	AssociationTypeIDMeetingToCompany = 188
+	AssociationTypeIDMeetingToDeal    = 212
+
+	AssociationTypeIDCallToContact = 194
+	AssociationTypeIDCallToCompany = 182
+	AssociationTypeIDCallToDeal    = 206
+
+	AssociationTypeIDDealToContact = 3
+	AssociationTypeIDDealToCompany = 341
+
+	AssociationTypeIDCompanyToContact = 280
+	AssociationTypeIDCompanyToDeal    = 342
+
+	AssociationTypeIDContactToCompany = 279
+	AssociationTypeIDContactToDeal    = 4
+)
+
+// CreateAssociation is an association to be made when creating an object.
+// Types specifies the association type, either a HubSpot-defined one or a label defined in the portal.
+type CreateAssociation struct {
+	To    AssociationTo     `json:"to"`
+	Types []AssociationSpec `json:"types"`
+}
+
+// AssociationTo is the object to be associated with.
+type AssociationTo struct {
+	ID string `json:"id"`
+}
+
+// AssociationSpec specifies the type of an association.
+type AssociationSpec struct {
+	Category AssociationCategory `json:"associationCategory"`
+	TypeID   int                 `json:"associationTypeId"`
+}
+
+// AssociationPair is a pair of objects to be associated with the given types.
+type AssociationPair struct {
+	From  AssociationTo     `json:"from"`
+	To    AssociationTo     `json:"to"`
+	Types []AssociationSpec `json:"types"`
+}
+
+// LabeledAssociation is an object associated with the object read by AssociationService.GetAll.
+// Types has every type the objects are associated with, so an association with a label
+// usually also has the unlabeled HubSpot-defined type.
+type LabeledAssociation struct {
+	ToObjectID string             `json:"toObjectId"`
+	Types      []AssociationLabel `json:"associationTypes"`
+}
+
+// UnmarshalJSON decodes the association, whose toObjectId is a number in the response.
+func (a *LabeledAssociation) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ToObjectID json.Number        `json:"toObjectId"`
+		Types      []AssociationLabel `json:"associationTypes"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	a.ToObjectID = raw.ToObjectID.String()
+	a.Types = raw.Types
+	return nil
+}
+
+// HasLabel reports whether the objects are associated with the type of the given label, e.g. "Decision maker".
+func (a *LabeledAssociation) HasLabel(label string) bool {
+	for _, t := range a.Types {
+		if t.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+// AssociationLabel is a type of an association.
+// Label is empty for the unlabeled types, such as the default HubSpot-defined ones.
+type AssociationLabel struct {
+	Category AssociationCategory `json:"category"`
+	TypeID   int                 `json:"typeId"`
+	Label    string              `json:"label,omitempty"`
+}
+
+// Spec returns the AssociationSpec of the type, to associate objects with it.
+func (l AssociationLabel) Spec() AssociationSpec {
+	return AssociationSpec{Category: l.Category, TypeID: l.TypeID}
+}
+
+type labeledAssociationList struct {
+	Results []*LabeledAssociation `json:"results"`
+	Paging  *Paging               `json:"paging,omitempty"`
+}
+
+type associationLabelList struct {
+	Results []*AssociationLabel `json:"results"`
+}
+
+type associationBatchRequest struct {
+	Inputs []AssociationPair `json:"inputs"`
+}
+
+// setSingleAssociationResults sets AssociationResults to the associations of the type when a single association type
+// is requested, so that the callers reading AssociationResults after a Get of one type keep getting them.
+func (r *ResponseResource) setSingleAssociationResults(types []string) {
+	if len(types) != 1 {
+		return
+	}
+	r.AssociationResults = r.Associations[types[0]].Results
+}
+
+// getAssociationResults gets the associated objects from the associations endpoint at path, following the pages until the last one.
+// The option is sent with the first request, and only the cursor is changed for the following ones,
+// unless HubSpot returns the link of the next page without a cursor, which is then requested as it is.
+func (c *Client) getAssociationResults(ctx context.Context, path string, option *RequestQueryOption) ([]AssociationResult, error) {
+	var results []AssociationResult
+	for {
+		page := &AssociationList{}
+		if err := c.CreateAndDoWithContext(ctx, http.MethodGet, path, nil, option, page); err != nil {
+			return nil, err
+		}
+		results = append(results, page.Results...)
+		next, nextOption, ok, err := c.nextPage(page.Paging, path, option)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return results, nil
+		}
+		path, option = next, nextOption
+	}
+}
+
+// followAssociations gets the remaining pages of the associations returned inline with the object at objectPath,
+// so that all associated objects are returned. The Paging of each AssociationList is cleared once it is complete.
+// The cursor of an inline page is the cursor of the associations endpoint of the object.
+func (c *Client) followAssociations(ctx context.Context, objectPath string, associations Associations) error {
+	for toType, list := range associations {
+		after, ok, err := c.nextCursor(list.Paging)
+		if err != nil {
+			return fmt.Errorf("unable to get the %s associations: %w", toType, err)
+		}
+		if !ok {
+			continue
+		}
+		option := &RequestQueryOption{Limit: associationReadPageLimit, After: after}
+		results, err := c.getAssociationResults(ctx, objectPath+"/"+associationBasePath+"/"+toType, option)
+		if err != nil {
+			return fmt.Errorf("unable to get the %s associations: %w", toType, err)
+		}
+		list.Results = append(list.Results, results...)
+		list.Paging = nil
+		associations[toType] = list
+	}
+	return nil
+}
+
+// GetAll gets all objects of toType associated with the object of fromType and fromID, following the pages until the last one.
+// Unlike the associations read with RequestQueryOption.Associations, the labels of each association are returned.
+// e.g. client.CRM.Association.GetAll(hubspot.ObjectTypeCompany, "companyID", hubspot.ObjectTypeContact)
+func (s *AssociationServiceOp) GetAll(fromType ObjectType, fromID string, toType ObjectType) ([]*LabeledAssociation, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", s.objectPath, fromType, fromID, associationBasePath, toType)
+	associations := []*LabeledAssociation{}
+	option := &RequestQueryOption{Limit: associationReadPageLimit}
+	for {
+		page := &labeledAssociationList{}
+		if err := s.client.Get(path, page, option); err != nil {
+			return nil, err
+		}
+		associations = append(associations, page.Results...)
+		next, nextOption, ok, err := s.client.nextPage(page.Paging, path, option)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return associations, nil
+		}
+		path, option = next, nextOption
+	}
+}
+
+// GetAllOfTypes gets the objects of toType associated with the object of fromType and fromID with any of the
+// association types of typeIDs, e.g. the type ID of a label looked up with ListAssociationTypes.
+// The Types of each association are narrowed to the types of typeIDs, and the objects associated with none of them are left out.
+// NOTE: This is only a client-side convenience filter. The v4 association read endpoint of HubSpot cannot filter by type,
+// so every association of toType is read, costing the same requests as GetAll, and the types are filtered afterwards.
+func (s *AssociationServiceOp) GetAllOfTypes(fromType ObjectType, fromID string, toType ObjectType, typeIDs []int) ([]*LabeledAssociation, error) {
+	all, err := s.GetAll(fromType, fromID, toType)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[int]bool, len(typeIDs))
+	for _, id := range typeIDs {
+		wanted[id] = true
+	}
+	associations := []*LabeledAssociation{}
+	for _, a := range all {
+		var types []AssociationLabel
+		for _, t := range a.Types {
+			if wanted[t.TypeID] {
+				types = append(types, t)
+			}
+		}
+		if len(types) != 0 {
+			associations = append(associations, &LabeledAssociation{ToObjectID: a.ToObjectID, Types: types})
+		}
+	}
+	return associations, nil
+}
+
+// CreateBatch associates the pairs of objects of fromType and toType.
+// The pairs are sent in batches of 100, so any number of pairs can be given.
+// When some pairs fail, the remaining batches are still sent and a *BatchError with the failures is returned.
+// e.g.
+//
+//	client.CRM.Association.CreateBatch(hubspot.ObjectTypeDeal, hubspot.ObjectTypeCompany, []hubspot.AssociationPair{{
+//		From:  hubspot.AssociationTo{ID: "dealID"},
+//		To:    hubspot.AssociationTo{ID: "companyID"},
+//		Types: []hubspot.AssociationSpec{{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDDealToCompany}},
+//	}})
+func (s *AssociationServiceOp) CreateBatch(fromType, toType ObjectType, pairs []AssociationPair) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/create", s.associationPath, fromType, toType, batchBasePath)
+	batchErr := &BatchError{}
+	for start := 0; start < len(pairs); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(pairs) {
+			end = len(pairs)
+		}
+		resource := &BatchResponse{}
+		if err := s.client.Post(path, &associationBatchRequest{Inputs: pairs[start:end]}, resource); err != nil {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				return err
+			}
+			batchErr.Errors = append(batchErr.Errors, *apiErr)
+			continue
+		}
+		batchErr.Errors = append(batchErr.Errors, resource.Errors...)
+	}
+	if len(batchErr.Errors) != 0 {
+		return batchErr
+	}
+	return nil
+}
+
+// ListAssociationTypes gets the association types defined between the objects of fromType and toType, including the labels.
+// The type IDs of USER_DEFINED labels differ per portal, so use this to look up the AssociationSpec of a label
+// instead of hardcoding its type ID.
+// The association types are cached per pair of object types for associationTypesTTL, so that the type ID of a label
+// is resolved before each association without a request, and the labels defined since are seen once it expires.
+// The errors are not cached.
+// e.g. resolve the type of the "Primary" label between companies and deals
+//
+//	labels, err := client.CRM.Association.ListAssociationTypes(hubspot.ObjectTypeCompany, hubspot.ObjectTypeDeal)
+//	for _, l := range labels {
+//		if l.Label == "Primary" {
+//			spec := l.Spec()
+//		}
+//	}
+func (s *AssociationServiceOp) ListAssociationTypes(fromType, toType ObjectType) ([]*AssociationLabel, error) {
+	key := [2]ObjectType{fromType, toType}
+	if cached, ok := s.associationTypes.Load(key); ok {
+		if entry := cached.(*associationTypesEntry); timeNow().Before(entry.expiresAt) {
+			return copyAssociationLabels(entry.labels), nil
+		}
+	}
+	resource := &associationLabelList{}
+	if err := s.client.Get(fmt.Sprintf("%s/%s/%s/labels", s.associationPath, fromType, toType), resource, nil); err != nil {
+		return nil, err
+	}
+	s.associationTypes.Store(key, &associationTypesEntry{
+		labels:    copyAssociationLabels(resource.Results),
+		expiresAt: timeNow().Add(associationTypesTTL),
+	})
+	return resource.Results, nil
+}
+
+// associationTypesEntry is the association types of a pair of object types cached by ListAssociationTypes.
+type associationTypesEntry struct {
+	labels    []*AssociationLabel
+	expiresAt time.Time
+}
+
+// copyAssociationLabels copies the labels, so that the cached ones are not changed by the caller.
+func copyAssociationLabels(labels []*AssociationLabel) []*AssociationLabel {
+	copied := make([]*AssociationLabel, 0, len(labels))
+	for _, l := range labels {
+		l := *l
+		copied = append(copied, &l)
+	}
+	return copied
+}
@@ -0,0 +1,224 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestAssociationServiceOp_CreateBatch(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusMultiStatus,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"COMPLETE","results":[],"numErrors":1,"errors":[{"status":"error","category":"VALIDATION_ERROR","message":"deal001 was not found"}]}`),
+	}
+	pairs := make([]hubspot.AssociationPair, 150)
+	for i := range pairs {
+		pairs[i] = hubspot.AssociationPair{
+			From: hubspot.AssociationTo{ID: "deal" + strconv.Itoa(i)},
+			To:   hubspot.AssociationTo{ID: "company001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDDealToCompany},
+			},
+		}
+	}
+
+	err := hubspot.NewMockClient(conf).CRM.Association.CreateBatch(hubspot.ObjectTypeDeal, hubspot.ObjectTypeCompany, pairs)
+	var batchErr *hubspot.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("CreateBatch() error mismatch: want *BatchError got %v", err)
+	}
+	if len(batchErr.Errors) != 2 || batchErr.Errors[0].Message != "deal001 was not found" {
+		t.Errorf("CreateBatch() error mismatch: want an error of each batch got %v", batchErr.Errors)
+	}
+
+	if len(conf.Requests) != 2 {
+		t.Fatalf("CreateBatch() requests mismatch: want 2 got %d", len(conf.Requests))
+	}
+	for i, wantInputs := range []int{100, 50} {
+		req := conf.Requests[i]
+		if want := "/crm/v4/associations/deals/companies/batch/create"; req.URL.Path != want {
+			t.Errorf("CreateBatch() request path mismatch: want %s got %s", want, req.URL.Path)
+		}
+		body := struct {
+			Inputs []hubspot.AssociationPair `json:"inputs"`
+		}{}
+		if err := json.Unmarshal(req.Body, &body); err != nil {
+			t.Fatalf("json.Unmarshal() error: %s", err)
+		}
+		if len(body.Inputs) != wantInputs {
+			t.Errorf("CreateBatch() inputs mismatch: want %d got %d", wantInputs, len(body.Inputs))
+		}
+	}
+	if want := `{"inputs":[{"from":{"id":"deal0"},"to":{"id":"company001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":341}]},`; !strings.HasPrefix(string(conf.Requests[0].Body), want) {
+		t.Errorf("CreateBatch() request body mismatch: want prefix %s got %s", want, string(conf.Requests[0].Body))
+	}
+}
+
+func TestAssociationServiceOp_GetAll(t *testing.T) {
+	pages := map[string][]byte{
+		"":      []byte(`{"results":[{"toObjectId":201,"associationTypes":[{"category":"HUBSPOT_DEFINED","typeId":280,"label":null},{"category":"USER_DEFINED","typeId":17,"label":"Decision maker"}]}],"paging":{"next":{"after":"page2"}}}`),
+		"page2": []byte(`{"results":[{"toObjectId":202,"associationTypes":[{"category":"USER_DEFINED","typeId":18,"label":"Billing contact"}]}]}`),
+	}
+	want := []*hubspot.LabeledAssociation{
+		{
+			ToObjectID: "201",
+			Types: []hubspot.AssociationLabel{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDCompanyToContact},
+				{Category: hubspot.AssociationCategoryUserDefined, TypeID: 17, Label: "Decision maker"},
+			},
+		},
+		{
+			ToObjectID: "202",
+			Types:      []hubspot.AssociationLabel{{Category: hubspot.AssociationCategoryUserDefined, TypeID: 18, Label: "Billing contact"}},
+		},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages)).CRM.Association.GetAll(hubspot.ObjectTypeCompany, "company001", hubspot.ObjectTypeContact)
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+	if !got[0].HasLabel("Decision maker") || got[1].HasLabel("Decision maker") {
+		t.Error("HasLabel() mismatch: want only the first association labeled Decision maker")
+	}
+}
+
+func TestAssociationServiceOp_GetAllOfTypes(t *testing.T) {
+	pages := map[string][]byte{
+		"":      []byte(`{"results":[{"toObjectId":201,"associationTypes":[{"category":"HUBSPOT_DEFINED","typeId":280,"label":null},{"category":"USER_DEFINED","typeId":17,"label":"Decision maker"}]}],"paging":{"next":{"after":"page2"}}}`),
+		"page2": []byte(`{"results":[{"toObjectId":202,"associationTypes":[{"category":"HUBSPOT_DEFINED","typeId":280,"label":null},{"category":"USER_DEFINED","typeId":18,"label":"Billing contact"}]}]}`),
+	}
+	tests := []struct {
+		name    string
+		typeIDs []int
+		want    []*hubspot.LabeledAssociation
+	}{
+		{
+			name:    "Only the associations with a label",
+			typeIDs: []int{17},
+			want: []*hubspot.LabeledAssociation{
+				{ToObjectID: "201", Types: []hubspot.AssociationLabel{{Category: hubspot.AssociationCategoryUserDefined, TypeID: 17, Label: "Decision maker"}}},
+			},
+		},
+		{
+			name:    "Several types",
+			typeIDs: []int{17, 18},
+			want: []*hubspot.LabeledAssociation{
+				{ToObjectID: "201", Types: []hubspot.AssociationLabel{{Category: hubspot.AssociationCategoryUserDefined, TypeID: 17, Label: "Decision maker"}}},
+				{ToObjectID: "202", Types: []hubspot.AssociationLabel{{Category: hubspot.AssociationCategoryUserDefined, TypeID: 18, Label: "Billing contact"}}},
+			},
+		},
+		{
+			name:    "No association of the type",
+			typeIDs: []int{19},
+			want:    []*hubspot.LabeledAssociation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages))
+			got, err := c.CRM.Association.GetAllOfTypes(hubspot.ObjectTypeCompany, "company001", hubspot.ObjectTypeContact, tt.typeIDs)
+			if err != nil {
+				t.Fatalf("GetAllOfTypes() unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetAllOfTypes() response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestAssociationServiceOp_ListAssociationTypes(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"category":"HUBSPOT_DEFINED","typeId":279,"label":null},{"category":"USER_DEFINED","typeId":17,"label":"Decision maker"}]}`),
+	}
+	want := []*hubspot.AssociationLabel{
+		{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDContactToCompany},
+		{Category: hubspot.AssociationCategoryUserDefined, TypeID: 17, Label: "Decision maker"},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Association.ListAssociationTypes(hubspot.ObjectTypeContact, hubspot.ObjectTypeCompany)
+	if err != nil {
+		t.Fatalf("ListAssociationTypes() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListAssociationTypes() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v4/associations/contacts/companies/labels"; conf.Requests[0].URL.Path != want {
+		t.Errorf("ListAssociationTypes() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestAssociationServiceOp_ListAssociationTypes_Cache(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"category":"HUBSPOT_DEFINED","typeId":341,"label":null},{"category":"HUBSPOT_DEFINED","typeId":5,"label":"Primary"}]}`),
+	}
+	want := []*hubspot.AssociationLabel{
+		{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: 341},
+		{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: 5, Label: "Primary"},
+	}
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	defer func(timeNow func() time.Time) { *hubspot.ExportTimeNow = timeNow }(*hubspot.ExportTimeNow)
+	*hubspot.ExportTimeNow = func() time.Time { return now }
+	c := hubspot.NewMockClient(conf)
+
+	for i := 0; i < 2; i++ {
+		got, err := c.CRM.Association.ListAssociationTypes(hubspot.ObjectTypeCompany, hubspot.ObjectTypeDeal)
+		if err != nil {
+			t.Fatalf("ListAssociationTypes() unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ListAssociationTypes() response mismatch (-want +got):%s", diff)
+		}
+		got[0].TypeID = 0
+	}
+	if len(conf.Requests) != 1 {
+		t.Errorf("ListAssociationTypes() requests mismatch: want 1 request for the cached types got %d", len(conf.Requests))
+	}
+
+	if _, err := c.CRM.Association.ListAssociationTypes(hubspot.ObjectTypeDeal, hubspot.ObjectTypeCompany); err != nil {
+		t.Fatalf("ListAssociationTypes() unexpected error: %s", err)
+	}
+	if want := "/crm/v4/associations/deals/companies/labels"; len(conf.Requests) != 2 || conf.Requests[1].URL.Path != want {
+		t.Errorf("ListAssociationTypes() request mismatch: want a request to %s for another pair got %v", want, conf.Requests)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := c.CRM.Association.ListAssociationTypes(hubspot.ObjectTypeCompany, hubspot.ObjectTypeDeal); err != nil {
+		t.Fatalf("ListAssociationTypes() unexpected error: %s", err)
+	}
+	if len(conf.Requests) != 3 {
+		t.Errorf("ListAssociationTypes() requests mismatch: want a request after the cache expired got %d requests", len(conf.Requests))
+	}
+
+	wantSpec := hubspot.AssociationSpec{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: 5}
+	if diff := cmp.Diff(wantSpec, want[1].Spec()); diff != "" {
+		t.Errorf("Spec() mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestAssociations_IDs(t *testing.T) {
+	associations := hubspot.Associations{
+		"contacts": {Results: []hubspot.AssociationResult{{ID: "contact001"}, {ID: "contact002"}}},
+	}
+	if diff := cmp.Diff([]string{"contact001", "contact002"}, associations.IDs("contacts")); diff != "" {
+		t.Errorf("IDs() mismatch (-want +got):%s", diff)
+	}
+	if got := associations.IDs("deals"); got != nil {
+		t.Errorf("IDs() mismatch: want nil for a type not returned got %v", got)
+	}
+}
@@ -1,8 +1,10 @@
 package hubspot
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 type Authenticator interface {
@@ -15,7 +17,7 @@ func SetOAuth(config *OAuthConfig) AuthMethod {
 	return func(c *Client) {
 		c.authenticator = &OAuth{
 			retriever: &OAuthTokenManager{
-				oauthPath:  fmt.Sprintf("%s/%s", c.baseURL.String(), oauthTokenPath),
+				oauthPath:  fmt.Sprintf("%s/%s", strings.TrimSuffix(c.baseURL.String(), "/"), oauthTokenPath),
 				HTTPClient: c.HTTPClient,
 				Config:     config,
 			},
@@ -31,7 +33,7 @@ func SetAPIKey(key string) AuthMethod {
 		} */
 		c.authenticator = &OAuth{
 			retriever: &OAuthTokenManager{
-				oauthPath:  fmt.Sprintf("%s/%s", c.baseURL.String(), oauthTokenPath),
+				oauthPath:  fmt.Sprintf("%s/%s", strings.TrimSuffix(c.baseURL.String(), "/"), oauthTokenPath),
 				HTTPClient: c.HTTPClient,
 				Token:      &OAuthToken{AccessToken: key},
 			},
@@ -62,3 +64,18 @@ func (a *APIKey) SetAuthentication(r *http.Request) error {
 	r.URL.RawQuery = q.Encode()
 	return nil
 }
+
+// unauthenticatedKey is the context key marking the requests to send without the credentials of the client.
+type unauthenticatedKey struct{}
+
+// withoutAuthentication returns a context whose requests are sent without the credentials of the client,
+// e.g. to an API hosted apart from the others that takes no authentication.
+func withoutAuthentication(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unauthenticatedKey{}, true)
+}
+
+// isUnauthenticated reports whether the requests of the context are sent without the credentials.
+func isUnauthenticated(ctx context.Context) bool {
+	unauthenticated, _ := ctx.Value(unauthenticatedKey{}).(bool)
+	return unauthenticated
+}
@@ -0,0 +1,290 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	batchBasePath = "batch"
+
+	// maxBatchSize is the maximum number of inputs HubSpot accepts in a batch request.
+	maxBatchSize = 100
+
+	// defaultBatchArchiveLimit is the number of objects a batch archive is allowed without BatchArchiveOption.Force
+	// when the limit is not set by WithBatchArchiveLimit.
+	defaultBatchArchiveLimit = 10
+)
+
+// BatchResponse is the response of a batch request.
+// When some inputs failed, HubSpot responds with 207 Multi-Status and the failures are set in Errors.
+type BatchResponse struct {
+	Status      string             `json:"status,omitempty"`
+	Results     []ResponseResource `json:"results,omitempty"`
+	NumErrors   int                `json:"numErrors,omitempty"`
+	Errors      []APIError         `json:"errors,omitempty"`
+	StartedAt   *HsTime            `json:"startedAt,omitempty"`
+	CompletedAt *HsTime            `json:"completedAt,omitempty"`
+}
+
+// BatchError is returned when some inputs of a batch operation failed while the others succeeded.
+type BatchError struct {
+	Errors []APIError
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d batch error(s) occurred: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// BatchUpsertInput is an input of a batch upsert request.
+// The object whose IDProperty value equals ID is updated, or created if it does not exist.
+// ObjectWriteTraceID is returned by HubSpot in ErrContext.ObjectWriteTraceID of the errors of the input.
+type BatchUpsertInput struct {
+	IDProperty         string      `json:"idProperty"`
+	ID                 string      `json:"id"`
+	ObjectWriteTraceID string      `json:"objectWriteTraceId,omitempty"`
+	Properties         interface{} `json:"properties"`
+}
+
+// BatchUpsertRequest is the request body of a batch upsert request.
+type BatchUpsertRequest struct {
+	Inputs []BatchUpsertInput `json:"inputs"`
+}
+
+// newBatchUpsertRequest builds a batch upsert request keyed on the idProperty value of each object.
+// The objectWriteTraceId of each input is set from its ID by traceID.
+func newBatchUpsertRequest(objects []interface{}, idProperty string, traceID func(id string) string) (*BatchUpsertRequest, error) {
+	if len(objects) > maxBatchSize {
+		return nil, fmt.Errorf("too many inputs: %d, up to %d inputs are allowed", len(objects), maxBatchSize)
+	}
+	req := &BatchUpsertRequest{Inputs: make([]BatchUpsertInput, 0, len(objects))}
+	for i, object := range objects {
+		id, err := propertyValue(object, idProperty)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		req.Inputs = append(req.Inputs, BatchUpsertInput{IDProperty: idProperty, ID: id, ObjectWriteTraceID: traceID(id), Properties: object})
+	}
+	return req, nil
+}
+
+// batchUpdateInput is an input of a batch update request.
+// HubSpot looks up the object whose IDProperty value equals ID, or whose object ID equals ID if IDProperty is empty.
+type batchUpdateInput struct {
+	ID                 string      `json:"id"`
+	IDProperty         string      `json:"idProperty,omitempty"`
+	ObjectWriteTraceID string      `json:"objectWriteTraceId,omitempty"`
+	Properties         interface{} `json:"properties"`
+}
+
+// batchUpdateRequest is the request body of a batch update request.
+type batchUpdateRequest struct {
+	Inputs []batchUpdateInput `json:"inputs"`
+}
+
+// newBatchUpdateRequest builds a batch update request keyed on the idProperty value of each object,
+// or on its hs_object_id if idProperty is empty. The objectWriteTraceId of each input is set from its ID by traceID.
+func newBatchUpdateRequest(objects []interface{}, idProperty string, traceID func(id string) string) (*batchUpdateRequest, error) {
+	if len(objects) > maxBatchSize {
+		return nil, fmt.Errorf("too many inputs: %d, up to %d inputs are allowed", len(objects), maxBatchSize)
+	}
+	key := idProperty
+	if key == "" {
+		key = searchPropertyObjectID
+	}
+	req := &batchUpdateRequest{Inputs: make([]batchUpdateInput, 0, len(objects))}
+	for i, object := range objects {
+		id, err := propertyValue(object, key)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		req.Inputs = append(req.Inputs, batchUpdateInput{ID: id, IDProperty: idProperty, ObjectWriteTraceID: traceID(id), Properties: object})
+	}
+	return req, nil
+}
+
+// batchSetProperty sets the property to the value on the objects of the ids with batch update requests of up to
+// maxBatchSize objects each. When some objects fail, the remaining batches are still sent, and the results of the
+// others are returned with a *BatchError of the failures. A failed batch request is added to the *BatchError with the
+// IDs of its objects in APIError.Context.IDs when HubSpot does not report them.
+// The remaining batches are not sent after an authentication error (401 or 403) or an error that is not an *APIError,
+// such as a canceled context, and that error is returned with the results of the batches already updated,
+// so the result is non-nil alongside the error in every case.
+func (c *Client) batchSetProperty(path string, ids []string, property string, value interface{}) (*ResponseResourceMulti, error) {
+	properties := map[string]interface{}{property: value}
+	resource := &ResponseResourceMulti{Results: make([]ResponseResource, 0, len(ids))}
+	batchErr := &BatchError{}
+	for start := 0; start < len(ids); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		req := &batchUpdateRequest{Inputs: make([]batchUpdateInput, 0, end-start)}
+		for _, id := range ids[start:end] {
+			req.Inputs = append(req.Inputs, batchUpdateInput{ID: id, ObjectWriteTraceID: c.writeTraceID(id), Properties: properties})
+		}
+		page := &BatchResponse{}
+		if err := c.Post(path+"/"+batchBasePath+"/update", req, page); err != nil {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden) {
+				return resource, err
+			}
+			failed := *apiErr
+			if len(failed.Context.IDs) == 0 {
+				failed.Context.IDs = append([]string(nil), ids[start:end]...)
+			}
+			batchErr.Errors = append(batchErr.Errors, failed)
+			continue
+		}
+		resource.Results = append(resource.Results, page.Results...)
+		batchErr.Errors = append(batchErr.Errors, page.Errors...)
+	}
+	if len(batchErr.Errors) != 0 {
+		return resource, batchErr
+	}
+	return resource, nil
+}
+
+// propertyValue returns the value of the named property from a properties structure.
+// The structure is marshaled in the same way as the request payload, so the name must match the `hubspot` or `json` tag.
+func propertyValue(properties interface{}, name string) (string, error) {
+	b, err := json.Marshal(withPropertyMapping(properties))
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", err
+	}
+	raw, ok := fields[name]
+	if !ok {
+		return "", fmt.Errorf("property %q is not set", name)
+	}
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", fmt.Errorf("property %q is not a string: %w", name, err)
+	}
+	if v == "" {
+		return "", fmt.Errorf("property %q is empty", name)
+	}
+	return v, nil
+}
+
+// batchReadInput is an input of a batch read or archive request.
+type batchReadInput struct {
+	ID string `json:"id"`
+}
+
+// batchReadRequest is the request body of a batch read request.
+type batchReadRequest struct {
+	Properties            []string         `json:"properties"`
+	PropertiesWithHistory []string         `json:"propertiesWithHistory,omitempty"`
+	IDProperty            string           `json:"idProperty,omitempty"`
+	Inputs                []batchReadInput `json:"inputs"`
+}
+
+// batchReadResponse is the response of a batch read request, whose results are decoded after binding their properties.
+type batchReadResponse struct {
+	Results []json.RawMessage `json:"results"`
+	Errors  []APIError        `json:"errors,omitempty"`
+}
+
+// batchRead reads the objects of the ids in batches of maxBatchSize, and merges the results and errors of all batches.
+// The properties of each result are bound to a new structure of the same type as properties, in the same way as decodePage.
+// RequestQueryOption.Properties, PropertiesWithHistory and IDProperty are sent in the body of each batch.
+// Only Results, NumErrors and Errors of the returned BatchResponse are set.
+func (c *Client) batchRead(path string, ids []string, option *RequestQueryOption, properties interface{}) (*BatchResponse, error) {
+	resource := &BatchResponse{Results: make([]ResponseResource, 0, len(ids))}
+	for start := 0; start < len(ids); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		req := &batchReadRequest{
+			Properties:            option.Properties,
+			PropertiesWithHistory: option.PropertiesWithHistory,
+			IDProperty:            option.IDProperty,
+			Inputs:                make([]batchReadInput, 0, end-start),
+		}
+		for _, id := range ids[start:end] {
+			req.Inputs = append(req.Inputs, batchReadInput{ID: id})
+		}
+		page := &batchReadResponse{}
+		if err := c.Post(path+"/"+batchBasePath+"/read", req, page); err != nil {
+			return nil, err
+		}
+		for _, raw := range page.Results {
+			result := ResponseResource{Properties: newPropertiesOf(properties)}
+			if err := c.unmarshal(raw, &result); err != nil {
+				return nil, err
+			}
+			resource.Results = append(resource.Results, result)
+		}
+		resource.Errors = append(resource.Errors, page.Errors...)
+	}
+	resource.NumErrors = len(resource.Errors)
+	return resource, nil
+}
+
+// batchArchiveRequest is the request body of a batch archive request.
+type batchArchiveRequest struct {
+	Inputs []batchReadInput `json:"inputs"`
+}
+
+// BatchArchiveOption is the option of a batch archive.
+type BatchArchiveOption struct {
+	// IDProperty is a unique property such as a custom external ID, to archive the objects by its values instead of their IDs.
+	IDProperty string
+	// Force confirms archiving more objects than the limit set by WithBatchArchiveLimit.
+	Force bool
+}
+
+// batchArchive archives the objects of the ids in one request.
+// More objects than the archive limit of the client are only archived with BatchArchiveOption.Force,
+// and an error wrapping ErrArchiveNotConfirmed is returned otherwise without making any request.
+// HubSpot only accepts object IDs in a batch archive request, so if BatchArchiveOption.IDProperty is set, the object IDs
+// are first looked up with a batch read keyed on it, and the values not found are skipped.
+func (c *Client) batchArchive(path string, ids []string, option *BatchArchiveOption) error {
+	if option == nil {
+		option = &BatchArchiveOption{}
+	}
+	if len(ids) > maxBatchSize {
+		return fmt.Errorf("too many inputs: %d, up to %d inputs are allowed", len(ids), maxBatchSize)
+	}
+	if limit := c.batchArchiveLimit(); len(ids) > limit && !option.Force {
+		return fmt.Errorf("%w: %d objects are more than the limit of %d, set BatchArchiveOption.Force to archive them", ErrArchiveNotConfirmed, len(ids), limit)
+	}
+	if option.IDProperty != "" {
+		option := &RequestQueryOption{Properties: []string{searchPropertyObjectID}, IDProperty: option.IDProperty}
+		resource, err := c.batchRead(path, ids, option, nil)
+		if err != nil {
+			return err
+		}
+		ids = make([]string, 0, len(resource.Results))
+		for _, result := range resource.Results {
+			ids = append(ids, result.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	req := &batchArchiveRequest{Inputs: make([]batchReadInput, 0, len(ids))}
+	for _, id := range ids {
+		req.Inputs = append(req.Inputs, batchReadInput{ID: id})
+	}
+	return c.Post(path+"/"+batchBasePath+"/archive", req, nil)
+}
+
+// batchArchiveLimit returns the number of objects a batch archive is allowed without BatchArchiveOption.Force.
+func (c *Client) batchArchiveLimit() int {
+	if c.archiveLimit > 0 {
+		return c.archiveLimit
+	}
+	return defaultBatchArchiveLimit
+}
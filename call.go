@@ -0,0 +1,120 @@
+package hubspot
+
+const (
+	callBasePath = "calls"
+)
+
+// CallService is an interface of call engagement endpoints of the HubSpot API.
+// HubSpot call engagements log calls made with contacts.
+// It can also be associated with other CRM objects such as contact, company and deal.
+// Reference: https://developers.hubspot.com/docs/api/crm/calls
+type CallService interface {
+	Get(callID string, call interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	Create(call interface{}) (*ResponseResource, error)
+	CreateWithAssociations(call interface{}, associations []CreateAssociation) (*ResponseResource, error)
+	Update(callID string, call interface{}) (*ResponseResource, error)
+	Delete(callID string) error
+}
+
+// CallServiceOp handles communication with the call engagement related methods of the HubSpot API.
+type CallServiceOp struct {
+	callPath string
+	client   *Client
+}
+
+var _ CallService = (*CallServiceOp)(nil)
+
+// Call directions
+const (
+	CallDirectionInbound  = "INBOUND"
+	CallDirectionOutbound = "OUTBOUND"
+)
+
+// Call represents a HubSpot call engagement.
+type Call struct {
+	HsTimestamp        *HsTime `json:"hs_timestamp,omitempty"`
+	HsCallTitle        *HsStr  `json:"hs_call_title,omitempty"`
+	HsCallBody         *HsStr  `json:"hs_call_body,omitempty"`
+	HsCallDuration     *HsStr  `json:"hs_call_duration,omitempty"` // In milliseconds
+	HsCallDirection    *HsStr  `json:"hs_call_direction,omitempty"`
+	HsCallStatus       *HsStr  `json:"hs_call_status,omitempty"`
+	HsCallFromNumber   *HsStr  `json:"hs_call_from_number,omitempty"`
+	HsCallToNumber     *HsStr  `json:"hs_call_to_number,omitempty"`
+	HubspotOwnerID     *HsStr  `json:"hubspot_owner_id,omitempty"`
+	HsObjectID         *HsStr  `json:"hs_object_id,omitempty"`
+	HsCreateDate       *HsTime `json:"hs_createdate,omitempty"`
+	HsLastModifiedDate *HsTime `json:"hs_lastmodifieddate,omitempty"`
+}
+
+var defaultCallFields = []string{
+	"hs_timestamp",
+	"hs_call_title",
+	"hs_call_body",
+	"hs_call_duration",
+	"hs_call_direction",
+	"hs_call_status",
+	"hs_call_from_number",
+	"hs_call_to_number",
+	"hubspot_owner_id",
+	"hs_object_id",
+	"hs_createdate",
+	"hs_lastmodifieddate",
+}
+
+// Get gets a call engagement.
+// In order to bind the get content, a structure must be specified as an argument.
+// Also, if you want to gets a custom field, you need to specify the field name.
+// If you specify a non-existent field, it will be ignored.
+// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+func (s *CallServiceOp) Get(callID string, call interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: call}
+	if err := s.client.Get(s.callPath+"/"+callID, resource, option.setupProperties(defaultCallFields)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Create creates a new call engagement.
+// In order to bind the created content, a structure must be specified as an argument.
+// When using custom fields, please embed hubspot.Call in your own structure.
+// If hs_timestamp is not set, it is set to the current time, as HubSpot rejects the engagements without it.
+// Set HsTimestamp to log an engagement that happened at another time.
+func (s *CallServiceOp) Create(call interface{}) (*ResponseResource, error) {
+	return s.CreateWithAssociations(call, nil)
+}
+
+// CreateWithAssociations creates a new call engagement and associates it with other objects in the same request.
+// In order to bind the created content, a structure must be specified as an argument.
+// hs_timestamp defaults to the current time in the same way as Create.
+func (s *CallServiceOp) CreateWithAssociations(call interface{}, associations []CreateAssociation) (*ResponseResource, error) {
+	properties, err := withDefaultTimestamp(call)
+	if err != nil {
+		return nil, err
+	}
+	req := &RequestPayload{Properties: properties, Associations: associations}
+	resource := &ResponseResource{Properties: call}
+	if err := s.client.Post(s.callPath, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Update updates a call engagement.
+// In order to bind the updated content, a structure must be specified as an argument.
+// When using custom fields, please embed hubspot.Call in your own structure.
+func (s *CallServiceOp) Update(callID string, call interface{}) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: call}
+	resource := &ResponseResource{Properties: call}
+	if err := s.client.Patch(s.callPath+"/"+callID, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Delete deletes a call engagement.
+func (s *CallServiceOp) Delete(callID string) error {
+	if err := s.client.Delete(s.callPath + "/" + callID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestCallServiceOp_CreateWithAssociations(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"call001","properties":{"hs_call_title":"Discovery call","hs_call_duration":"3800","hs_call_direction":"OUTBOUND","hs_object_id":"call001","hs_timestamp":"2019-10-30T03:30:17.883Z"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`),
+	}
+	call := &hubspot.Call{
+		HsTimestamp:     hubspot.NewTime(time.Date(2019, 10, 30, 3, 30, 17, 883000000, time.UTC)),
+		HsCallTitle:     hubspot.NewString("Discovery call"),
+		HsCallDuration:  hubspot.NewString("3800"),
+		HsCallDirection: hubspot.NewString(hubspot.CallDirectionOutbound),
+	}
+	associations := []hubspot.CreateAssociation{
+		{
+			To: hubspot.AssociationTo{ID: "contact001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDCallToContact},
+			},
+		},
+	}
+	want := &hubspot.ResponseResource{
+		ID: "call001",
+		Properties: &hubspot.Call{
+			HsTimestamp:     &createdAt,
+			HsCallTitle:     hubspot.NewString("Discovery call"),
+			HsCallDuration:  hubspot.NewString("3800"),
+			HsCallDirection: hubspot.NewString(hubspot.CallDirectionOutbound),
+			HsObjectID:      hubspot.NewString("call001"),
+		},
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+	wantBody := `{"properties":{"hs_timestamp":"2019-10-30T03:30:17.883Z","hs_call_title":"Discovery call","hs_call_duration":"3800","hs_call_direction":"OUTBOUND"},"associations":[{"to":{"id":"contact001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":194}]}]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Call.CreateWithAssociations(call, associations)
+	if err != nil {
+		t.Fatalf("CreateWithAssociations() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("CreateWithAssociations() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/calls"; req.URL.Path != want {
+		t.Errorf("CreateWithAssociations() request path mismatch: want %s got %s", want, req.URL.Path)
+	}
+	if string(req.Body) != wantBody {
+		t.Errorf("CreateWithAssociations() request body mismatch: want %s got %s", wantBody, string(req.Body))
+	}
+}
+
+func TestCallServiceOp_Create_DefaultTimestamp(t *testing.T) {
+	defer hubspot.MockTimeNow()()
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"call001","properties":{"hs_call_title":"Discovery call","hs_timestamp":"2020-12-31T12:00:00Z"}}`),
+	}
+	wantBody := `{"properties":{"hs_call_title":"Discovery call","hs_timestamp":"2020-12-31T12:00:00Z"}}`
+
+	if _, err := hubspot.NewMockClient(conf).CRM.Call.Create(&hubspot.Call{HsCallTitle: hubspot.NewString("Discovery call")}); err != nil {
+		t.Fatalf("Create() unexpected error: %s", err)
+	}
+	if got := string(conf.Requests[0].Body); got != wantBody {
+		t.Errorf("Create() request body mismatch: want %s got %s", wantBody, got)
+	}
+}
@@ -1,11 +1,19 @@
 package hubspot
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 )
 
 const (
 	companyBasePath = "companies"
+
+	// companyDomainProperty is the property HubSpot dedupes the companies by.
+	companyDomainProperty = "domain"
 )
 
 // Company is an interface of company endpoints of the HubSpot API.
@@ -13,11 +21,27 @@ const (
 // Reference: https://developers.hubspot.com/docs/api/crm/companies
 type CompanyService interface {
 	Get(companyID string, owner interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetWithContacts(companyID string, company, contact interface{}, option, contactOption *RequestQueryOption) (*ResponseResource, *BatchResponse, error)
+	GetMany(ctx context.Context, companyIDs []string, concurrency int, option *RequestQueryOption) ([]*ResponseResource, error)
 	GetAll(company interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error)
+	ListIDs(option *RequestQueryOption) ([]string, error)
 	Search(company interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error)
+	SearchMany(ctx context.Context, options []*RequestSearchOption, concurrency int) ([]*ResponseResourceMulti, error)
 	Create(company interface{}) (*ResponseResource, error)
+	CreateWithAssociations(company interface{}, associations []CreateAssociation) (*ResponseResource, error)
 	Update(companyID string, company interface{}) (*ResponseResource, error)
+	ClearProperty(companyID, propertyName string) error
+	UpdateFieldIfEmpty(companyID, property string, value interface{}) error
 	Delete(companyID string) error
+	Upsert(company interface{}, idProperty string) (*ResponseResource, error)
+	BatchUpsert(companies []interface{}, idProperty string) (*BatchResponse, error)
+	BatchRead(company interface{}, companyIDs []string, option *RequestQueryOption) (*BatchResponse, error)
+	BatchUpdate(companies []interface{}, idProperty string) (*BatchResponse, error)
+	BatchArchive(companyIDs []string, option *BatchArchiveOption) error
+	SetPropertyForAll(companyIDs []string, property string, value interface{}) (*ResponseResourceMulti, error)
+	Stream(ctx context.Context, option *RequestQueryOption) (<-chan *ResponseResource, <-chan error)
+	RecentlyModified(since time.Time, option *RequestQueryOption) *CompanyPager
+	SearchDeep(option *RequestSearchOption) *CompanyPager
 }
 
 // OwnerServiceOp handles communication with the product related methods of the HubSpot API.
@@ -71,24 +95,114 @@ var defaultCompanyFields = []string{
 // Also, if you want to gets a custom field, you need to specify the field name.
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+// The association types, e.g. &hubspot.RequestQueryOption{Associations: []string{"contacts", "deals"}},
+// are returned with the company keyed by type, e.g. resource.Associations["contacts"], however many types are given.
+// When a single association type is given, its associations are also set in ResponseResource.AssociationResults.
+// The associations are paginated by HubSpot, and the pages are followed so that all associated objects are returned.
 func (s *CompanyServiceOp) Get(companyID string, company interface{}, option *RequestQueryOption) (*ResponseResource, error) {
-	resource := &ResponseResource{Properties: company}
+	return s.get(context.Background(), companyID, company, option)
+}
+
+func (s *CompanyServiceOp) get(ctx context.Context, companyID string, company interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	if option == nil {
+		option = &RequestQueryOption{}
+	}
 	path := s.companyPath + "/" + companyID
-	if len(option.Associations) != 0 {
-		path += "/associations/" + option.Associations[0]
-		resource = &ResponseResource{}
+	resource := &ResponseResource{Properties: company}
+	if err := getWithHistory(ctx, s.client, path, resource, option.setupProperties(defaultCompanyFields)); err != nil {
+		return nil, err
 	}
-	if err := s.client.Get(path, resource, option.setupProperties(defaultCompanyFields)); err != nil {
+	if err := s.client.followAssociations(ctx, path, resource.Associations); err != nil {
 		return nil, err
 	}
+	resource.setSingleAssociationResults(option.Associations)
 	return resource, nil
 }
 
+// GetWithContacts gets a company and the contacts associated with it, e.g. for the detail view of an account.
+// The company is bound to company in the same way as Get, with its contacts in resource.Associations["contacts"]
+// along with the association types of option.Associations,
+// and the contacts are read with batch read requests of up to 100 contacts each, binding them in the same way as BatchRead.
+// contactOption specifies the contact properties to get in the same way as option for the company.
+// It takes 2 requests for up to 100 contacts, instead of one Get per contact.
+func (s *CompanyServiceOp) GetWithContacts(companyID string, company, contact interface{}, option, contactOption *RequestQueryOption) (*ResponseResource, *BatchResponse, error) {
+	opts := RequestQueryOption{}
+	if option != nil {
+		opts = *option
+	}
+	// The contacts are requested along with the association types of option, copied so that option is left unchanged.
+	hasContacts := false
+	for _, t := range opts.Associations {
+		hasContacts = hasContacts || t == string(ObjectTypeContact)
+	}
+	if !hasContacts {
+		opts.Associations = append(append([]string{}, opts.Associations...), string(ObjectTypeContact))
+	}
+	resource, err := s.get(context.Background(), companyID, company, &opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A contact is listed once per association type, e.g. with and without a label.
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, id := range resource.Associations.IDs(string(ObjectTypeContact)) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	contactPath := fmt.Sprintf("%s/%s/%s/%s", crmBasePath, s.client.apiVersion, objectsBasePath, contactBasePath)
+	contacts, err := s.client.batchRead(contactPath, ids, contactOption.setupProperties(defaultContactFields), contact)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get the contacts of company %s: %w", companyID, err)
+	}
+	return resource, contacts, nil
+}
+
+// GetMany gets the companies of the given IDs by running Get in parallel.
+// At most concurrency requests run at the same time, and 5 is used if concurrency is not positive.
+// The properties of each company are bound to *Company, and the results are in the same order as companyIDs.
+// If some requests fail, the result of those companies is nil and the errors are returned as MultiError.
+// When the context is canceled, the companies not yet requested are not requested.
+func (s *CompanyServiceOp) GetMany(ctx context.Context, companyIDs []string, concurrency int, option *RequestQueryOption) ([]*ResponseResource, error) {
+	results := make([]*ResponseResource, len(companyIDs))
+	err := s.client.runConcurrent(ctx, len(companyIDs), concurrency, func(ctx context.Context, i int) error {
+		resource, err := s.get(ctx, companyIDs[i], &Company{}, option)
+		if err != nil {
+			return err
+		}
+		results[i] = resource
+		return nil
+	})
+	return results, err
+}
+
 // Create creates a new company.
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Contact in your own structure.
+// If enabled with WithDomainNormalization, the domain is normalized by NormalizeDomain before it is sent,
+// so that HubSpot dedupes the company by its domain.
+// The ID of the created company is always set in ResponseResource.ID, even if the structure has no HsObjectID field.
 func (s *CompanyServiceOp) Create(company interface{}) (*ResponseResource, error) {
-	req := &RequestPayload{Properties: company}
+	return s.CreateWithAssociations(company, nil)
+}
+
+// CreateWithAssociations creates a new company and associates it with other objects in the same request.
+// Unlike associating after Create, no company is left without its associations if the association fails.
+// In order to bind the created content, a structure must be specified as an argument.
+// e.g. associate with a contact
+//
+//	[]hubspot.CreateAssociation{{
+//		To:    hubspot.AssociationTo{ID: "contactID"},
+//		Types: []hubspot.AssociationSpec{{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDCompanyToContact}},
+//	}}
+func (s *CompanyServiceOp) CreateWithAssociations(company interface{}, associations []CreateAssociation) (*ResponseResource, error) {
+	properties, err := s.requestProperties(company)
+	if err != nil {
+		return nil, err
+	}
+	req := &RequestPayload{Properties: properties, Associations: associations}
 	resource := &ResponseResource{Properties: company}
 	if err := s.client.Post(s.companyPath, req, resource); err != nil {
 		return nil, err
@@ -99,8 +213,15 @@ func (s *CompanyServiceOp) Create(company interface{}) (*ResponseResource, error
 // Update updates a company.
 // In order to bind the updated content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Company in your own structure.
+// Only the non-nil fields are sent, so set a field to hubspot.ClearString() to clear its property,
+// e.g. &hubspot.Company{Phone: hubspot.ClearString()}
+// The domain is normalized as on Create if enabled with WithDomainNormalization.
 func (s *CompanyServiceOp) Update(companyID string, company interface{}) (*ResponseResource, error) {
-	req := &RequestPayload{Properties: company}
+	properties, err := s.requestProperties(company)
+	if err != nil {
+		return nil, err
+	}
+	req := &RequestPayload{Properties: properties}
 	resource := &ResponseResource{Properties: company}
 	if err := s.client.Patch(s.companyPath+"/"+companyID, req, resource); err != nil {
 		return nil, err
@@ -108,16 +229,121 @@ func (s *CompanyServiceOp) Update(companyID string, company interface{}) (*Respo
 	return resource, nil
 }
 
+// requestProperties returns the properties of the company to send on Create and Update,
+// with the domain normalized by NormalizeDomain if enabled with WithDomainNormalization.
+func (s *CompanyServiceOp) requestProperties(company interface{}) (interface{}, error) {
+	if !s.client.normalizeDomain {
+		return company, nil
+	}
+	b, err := json.Marshal(withPropertyMapping(company))
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	var domain string
+	if raw, ok := properties[companyDomainProperty]; !ok || json.Unmarshal(raw, &domain) != nil {
+		return company, nil
+	}
+	if properties[companyDomainProperty], err = json.Marshal(NormalizeDomain(domain)); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// NormalizeDomain normalizes a company domain the way HubSpot expects it, so that the same company is not
+// duplicated by different forms of its domain, e.g. "https://www.Acme.com/" becomes "acme.com".
+// The domain is lowercased, and the scheme, the "www." prefix and the path are removed.
+func NormalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if i := strings.Index(domain, "://"); i >= 0 {
+		domain = domain[i+len("://"):]
+	}
+	if i := strings.IndexAny(domain, "/?#"); i >= 0 {
+		domain = domain[:i]
+	}
+	return strings.TrimPrefix(domain, "www.")
+}
+
+// ClearProperty clears the value of a single property of a company, e.g. "trial_end_date".
+// HubSpot clears a property when it is updated with an empty string, so no struct has to be built for it.
+// The errors are the same as Update.
+func (s *CompanyServiceOp) ClearProperty(companyID, propertyName string) error {
+	if propertyName == "" {
+		return errors.New("the property name is empty")
+	}
+	req := &RequestPayload{Properties: map[string]string{propertyName: ""}}
+	return s.client.Patch(s.companyPath+"/"+companyID, req, nil)
+}
+
+// UpdateFieldIfEmpty sets the property of a company to value only if the property has no value yet,
+// e.g. so that a value entered manually by the sales team is not overwritten.
+// It reads the current value first, and returns an error wrapping ErrFieldAlreadySet without updating when it is set.
+// NOTE: The read and the update are two requests, so a value set by someone else in between is overwritten.
+func (s *CompanyServiceOp) UpdateFieldIfEmpty(companyID, property string, value interface{}) error {
+	if property == "" {
+		return errors.New("the property name is empty")
+	}
+	current := map[string]interface{}{}
+	if _, err := s.Get(companyID, &current, &RequestQueryOption{CustomProperties: []string{property}}); err != nil {
+		return err
+	}
+	if v, ok := current[property]; ok && v != nil && v != "" {
+		return fmt.Errorf("%w: %s of company %s", ErrFieldAlreadySet, property, companyID)
+	}
+	req := &RequestPayload{Properties: map[string]interface{}{property: value}}
+	return s.client.Patch(s.companyPath+"/"+companyID, req, nil)
+}
+
+// ListIDs gets the IDs of all companies, following the pages until the last one.
+// Only hs_object_id is requested, so it is much cheaper than GetAll when only the set of companies is needed,
+// e.g. to reconcile them with a local database.
+// RequestQueryOption.Limit is the page size, up to 100, and 100 is used if not set.
+// RequestQueryOption.Archived lists the archived companies instead, and the properties and associations are ignored.
+func (s *CompanyServiceOp) ListIDs(option *RequestQueryOption) ([]string, error) {
+	opts := &RequestQueryOption{Limit: maxListLimit}
+	if option != nil {
+		opts.Archived = option.Archived
+		if option.Limit != 0 {
+			opts.Limit = option.Limit
+		}
+	}
+	opts.Properties = []string{searchPropertyObjectID}
+
+	ids := []string{}
+	pager := newCompanyListPager(s, opts)
+	for pager.HasNext() {
+		companies, err := pager.Next(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		for _, company := range companies {
+			ids = append(ids, company.ID)
+		}
+	}
+	return ids, nil
+}
+
 // Get gets all companies.
 // In order to bind the get content, a structure must be specified as an argument.
 // Also, if you want to gets a custom field, you need to specify the field name.
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+// If there is no company, an empty Results is returned without error.
+// RequestQueryOption.Limit is up to 100, and an error wrapping ErrInvalidLimit is returned for a larger one.
 func (s *CompanyServiceOp) GetAll(company interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error) {
 	//result := []interface{}{}
 	//result = append(result, company)
 	//resource := &ResponseResourceAll{Results: result}
 	resource := &ResponseResourceMulti{}
+	if option == nil {
+		option = &RequestQueryOption{}
+	}
+	if err := option.validateLimit(maxListLimit); err != nil {
+		return nil, err
+	}
 	if len(option.Properties) == 0 {
 		option = option.setupProperties(defaultCompanyFields)
 	}
@@ -125,6 +351,10 @@ func (s *CompanyServiceOp) GetAll(company interface{}, option *RequestQueryOptio
 	if err := s.client.Get(s.companyPath, resource, option); err != nil {
 		return nil, err
 	}
+	// Make sure callers can range over the results without nil checks when there is no company.
+	if resource.Results == nil {
+		resource.Results = []ResponseResource{}
+	}
 	return resource, nil
 }
 
@@ -132,17 +362,37 @@ func (s *CompanyServiceOp) GetAll(company interface{}, option *RequestQueryOptio
 // In order to bind the get content, a structure must be specified as an argument.
 // Also, if you want to gets a custom field, you need to specify the field name.
 // If you specify a non-existent field, it will be ignored.
-// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+// The default fields are requested in the same way as Get.
+// e.g. &hubspot.RequestSearchOption{ CustomProperties: []string{"custom_a", "custom_b"}}
+// An error is returned without making the request if the filter groups exceed the limits of HubSpot.
 func (s *CompanyServiceOp) Search(company interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error) {
-	resources := []ResponseResource{}
-	resources = append(resources, ResponseResource{Properties: company})
-	resource := &ResponseResourceMulti{Results: resources}
-	if err := s.client.Post(s.companyPath+"/search", option, resource); err != nil {
+	resource := &ResponseResourceMulti{}
+	if err := s.client.search(s.companyPath, option.setupProperties(defaultCompanyFields), company, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
 }
 
+// SearchMany runs the searches of the given options in parallel, e.g. the filters of several segments.
+// HubSpot has no batch search, so each option is a search request, and the limits of HubSpot apply to each of them.
+// At most concurrency requests run at the same time, and 5 is used if concurrency is not positive.
+// The properties of each company are bound to *Company, and the results are in the same order as options.
+// If some searches fail, the result of those options is nil and the errors are returned as MultiError,
+// whose indexes are those of options. When the context is canceled, the searches not yet started are not started.
+// The searches rate limited by HubSpot are retried in the same way as RunConcurrent, and paced by WithRateLimit if set.
+func (s *CompanyServiceOp) SearchMany(ctx context.Context, options []*RequestSearchOption, concurrency int) ([]*ResponseResourceMulti, error) {
+	results := make([]*ResponseResourceMulti, len(options))
+	err := s.client.runConcurrent(ctx, len(options), concurrency, func(ctx context.Context, i int) error {
+		resource := &ResponseResourceMulti{}
+		if err := s.client.searchWithContext(ctx, s.companyPath, options[i].setupProperties(defaultCompanyFields), &Company{}, resource); err != nil {
+			return err
+		}
+		results[i] = resource
+		return nil
+	})
+	return results, err
+}
+
 // Delete deletes a company.
 // A HubSpot internal Company ID must be specified.
 func (s *CompanyServiceOp) Delete(companyID string) error {
@@ -153,28 +403,159 @@ func (s *CompanyServiceOp) Delete(companyID string) error {
 	return nil
 }
 
-func (c *Company) AddProductName(name string) {
-	tmpProductNames := []string{}
-	if c.ProductNames != nil && c.ProductNames.String() != "" {
-		tmpProductNames = strings.Split(c.ProductNames.String(), ";")
+// Upsert updates the company whose idProperty value equals that of the given company, or creates it if it does not exist.
+// The idProperty must be a unique property such as a custom external ID, and its value must be set in the company.
+// Unlike Create, retrying Upsert does not create duplicate companies.
+// In order to bind the upserted content, a structure must be specified as an argument.
+func (s *CompanyServiceOp) Upsert(company interface{}, idProperty string) (*ResponseResource, error) {
+	req, err := newBatchUpsertRequest([]interface{}{company}, idProperty, s.client.writeTraceID)
+	if err != nil {
+		return nil, err
 	}
-	tmpProductNames = append(tmpProductNames, name)
-	c.ProductNames = NewString(strings.Join(tmpProductNames, ";"))
+	resource := &ResponseResourceMulti{Results: []ResponseResource{{Properties: company}}}
+	if err := s.client.Post(s.companyPath+"/"+batchBasePath+"/upsert", req, resource); err != nil {
+		return nil, err
+	}
+	if len(resource.Results) == 0 {
+		return nil, errors.New("no upsert result returned")
+	}
+	return &resource.Results[0], nil
 }
 
-func (c *Company) RemoveProductName(name string) {
-	if c.ProductNames == nil {
-		return
+// BatchUpsert updates or creates up to 100 companies in one request, keyed on the idProperty value of each company.
+// The idProperty must be a unique property, and its value must be set in every company.
+// ResponseResource.New of each result reports whether the company was created or updated.
+// The results are not guaranteed to be in the same order as the input, so match them by the idProperty value.
+// If some inputs failed, the other results are still returned and the failures are set in BatchResponse.Errors.
+func (s *CompanyServiceOp) BatchUpsert(companies []interface{}, idProperty string) (*BatchResponse, error) {
+	req, err := newBatchUpsertRequest(companies, idProperty, s.client.writeTraceID)
+	if err != nil {
+		return nil, err
 	}
-	tmpProductNames := strings.Split(c.ProductNames.String(), ";")
-	for i, v := range tmpProductNames {
-		if v == name {
-			tmpProductNames = append(tmpProductNames[:i], tmpProductNames[i+1:]...)
-		}
+	resource := &BatchResponse{}
+	if err := s.client.Post(s.companyPath+"/"+batchBasePath+"/upsert", req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// BatchRead gets the companies of the given IDs with batch read requests of up to 100 companies each.
+// The properties of each company are bound to a new structure of the same type as the argument,
+// and the properties to get are specified in the same way as Get.
+// RequestQueryOption.PropertiesWithHistory gets the history of the properties of every company into
+// ResponseResource.PropertiesWithHistory, which is much faster than getting the history one by one.
+// Set RequestQueryOption.IDProperty to read the companies by the values of a unique property instead of their IDs,
+// e.g. a custom external ID. The property is not checked by the client, HubSpot rejects the request if it is not unique.
+// The results are not guaranteed to be in the same order as companyIDs, and the companies not found are set in BatchResponse.Errors.
+func (s *CompanyServiceOp) BatchRead(company interface{}, companyIDs []string, option *RequestQueryOption) (*BatchResponse, error) {
+	return s.client.batchRead(s.companyPath, companyIDs, option.setupProperties(defaultCompanyFields), company)
+}
+
+// BatchUpdate updates up to 100 existing companies in one request, keyed on the idProperty value of each company.
+// The idProperty must be a unique property such as a custom external ID, and its value must be set in every company.
+// If idProperty is empty, the companies are keyed on their HsObjectID instead.
+// Unlike BatchUpsert, the companies not found are not created but set in BatchResponse.Errors.
+// The results are not guaranteed to be in the same order as the input.
+func (s *CompanyServiceOp) BatchUpdate(companies []interface{}, idProperty string) (*BatchResponse, error) {
+	req, err := newBatchUpdateRequest(companies, idProperty, s.client.writeTraceID)
+	if err != nil {
+		return nil, err
 	}
-	if len(tmpProductNames) == 0 {
-		c.ProductNames = nil
-		return
+	resource := &BatchResponse{}
+	if err := s.client.Post(s.companyPath+"/"+batchBasePath+"/update", req, resource); err != nil {
+		return nil, err
 	}
-	c.ProductNames = NewString(strings.Join(tmpProductNames, ";"))
+	return resource, nil
+}
+
+// BatchArchive archives up to 100 companies in one request.
+// As a safeguard, archiving more than 10 companies, or the limit set by WithBatchArchiveLimit, requires
+// BatchArchiveOption.Force, and an error wrapping ErrArchiveNotConfirmed is returned otherwise.
+// Set BatchArchiveOption.IDProperty to a unique property such as a custom external ID to archive the companies by its values
+// instead of their IDs.
+// NOTE: HubSpot only accepts company IDs to archive, so with IDProperty the companies are first looked up with BatchRead,
+// which costs one more request, and the values not found are ignored in the same way as the IDs not found.
+func (s *CompanyServiceOp) BatchArchive(companyIDs []string, option *BatchArchiveOption) error {
+	return s.client.batchArchive(s.companyPath, companyIDs, option)
+}
+
+// SetPropertyForAll sets the property to the same value on all companies of the given IDs,
+// e.g. client.CRM.Company.SetPropertyForAll(ids, "trial_status", "expired").
+// The companies are updated with batch update requests of up to 100 companies each, so any number of IDs can be given.
+// When some companies fail, the remaining batches are still sent, and the updated companies are returned with a *BatchError
+// whose APIError.Context.IDs has the IDs of the failed companies.
+// After an authentication error or a canceled context no more batches are sent, and the error is returned with the
+// companies updated so far, so check the returned result even when the error is non-nil.
+// The properties of each result are bound to a map, since only the given property is updated.
+func (s *CompanyServiceOp) SetPropertyForAll(companyIDs []string, property string, value interface{}) (*ResponseResourceMulti, error) {
+	return s.client.batchSetProperty(s.companyPath, companyIDs, property, value)
+}
+
+// Stream gets all companies page by page and sends them to the returned channel one at a time.
+// The properties of each company are bound to *Company, and the option is handled in the same way as GetAll.
+// The next page is not requested until the records of the current page are received, so memory usage stays flat.
+// Both channels are closed when all companies have been sent, the context is canceled or an error occurs.
+// In the latter two cases, the error is sent to the error channel before closing.
+func (s *CompanyServiceOp) Stream(ctx context.Context, option *RequestQueryOption) (<-chan *ResponseResource, <-chan error) {
+	out := make(chan *ResponseResource)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		pager := newCompanyListPager(s, option)
+		for pager.HasNext() {
+			companies, err := pager.Next(ctx)
+			if err != nil {
+				errc <- err
+				return
+			}
+			for _, company := range companies {
+				select {
+				case out <- company:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+
+	return out, errc
+}
+
+// AddProductName adds the name to the semicolon-separated product names.
+// If the name already exists, it will not be added again.
+func (c *Company) AddProductName(name string) {
+	c.ProductNames = c.ProductNames.AddToSet(name)
+}
+
+// RemoveProductName removes all occurrences of the name from the semicolon-separated product names.
+// If no product names remain, ProductNames is set to ClearString(), so that an update clears them in HubSpot.
+// It is left nil if it was not set.
+func (c *Company) RemoveProductName(name string) {
+	c.ProductNames = c.ProductNames.RemoveFromSet(name)
+}
+
+// RecentlyModified returns a pager over the companies modified at or after since, in ascending order of modification.
+// This is intended for incremental sync: store the largest ResponseResource.UpdatedAt seen and pass it as since next time.
+// The properties of each company are bound to *Company, and the properties to get are specified in the same way as GetAll.
+// RequestQueryOption.Limit is the page size, up to 200, and 200 is used if not set.
+// A larger limit is rejected by Next with an error wrapping ErrInvalidLimit.
+// Search results are capped at 10,000 per query, so the pager transparently restarts the search from the latest
+// modification time returned so far, skipping the companies already returned.
+func (s *CompanyServiceOp) RecentlyModified(since time.Time, option *RequestQueryOption) *CompanyPager {
+	return newCompanyModifiedPager(s, since, option)
+}
+
+// SearchDeep returns a pager over all companies matching the search, beyond the 10,000 results HubSpot search returns for a query.
+// The results are sorted by hs_object_id in ascending order, and each page is requested with a filter
+// hs_object_id > the last ID returned so far, added to every filter group.
+// Only the sort by hs_object_id in ascending order is allowed, and Next returns an error for other sorts.
+// The properties of each company are bound to *Company, and RequestSearchOption.Limit is the page size, up to 200,
+// and 200 is used if not set. A larger limit is rejected by the first Next with an error wrapping ErrInvalidLimit.
+// NOTE: Since each page is a new query, companies modified during the enumeration may be missed or returned twice.
+func (s *CompanyServiceOp) SearchDeep(option *RequestSearchOption) *CompanyPager {
+	return newCompanyDeepSearchPager(s, option)
 }
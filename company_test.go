@@ -0,0 +1,2060 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestCompany_AddProductName(t *testing.T) {
+	tests := []struct {
+		name         string
+		productNames *hubspot.HsStr
+		add          string
+		want         *hubspot.HsStr
+	}{
+		{
+			name:         "Success with nil product names",
+			productNames: nil,
+			add:          "a",
+			want:         hubspot.NewString("a"),
+		},
+		{
+			name:         "Success with existing product names",
+			productNames: hubspot.NewString("a;b"),
+			add:          "c",
+			want:         hubspot.NewString("a;b;c"),
+		},
+		{
+			name:         "Success with already added name",
+			productNames: hubspot.NewString("a;b"),
+			add:          "a",
+			want:         hubspot.NewString("a;b"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &hubspot.Company{ProductNames: tt.productNames}
+			c.AddProductName(tt.add)
+			if diff := cmp.Diff(tt.want, c.ProductNames); diff != "" {
+				t.Errorf("AddProductName() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompany_RemoveProductName(t *testing.T) {
+	tests := []struct {
+		name         string
+		productNames *hubspot.HsStr
+		remove       string
+		want         *hubspot.HsStr
+	}{
+		{
+			name:         "Success with nil product names",
+			productNames: nil,
+			remove:       "a",
+			want:         nil,
+		},
+		{
+			name:         "Success with single entry",
+			productNames: hubspot.NewString("a;b;c"),
+			remove:       "b",
+			want:         hubspot.NewString("a;c"),
+		},
+		{
+			name:         "Success with duplicate entries",
+			productNames: hubspot.NewString("a;b;c;b"),
+			remove:       "b",
+			want:         hubspot.NewString("a;c"),
+		},
+		{
+			name:         "Success with adjacent duplicate entries",
+			productNames: hubspot.NewString("a;b;b;c"),
+			remove:       "b",
+			want:         hubspot.NewString("a;c"),
+		},
+		{
+			name:         "Success with last entry removed",
+			productNames: hubspot.NewString("a;a"),
+			remove:       "a",
+			want:         hubspot.ClearString(),
+		},
+		{
+			name:         "Success with name not found",
+			productNames: hubspot.NewString("a;b"),
+			remove:       "c",
+			want:         hubspot.NewString("a;b"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &hubspot.Company{ProductNames: tt.productNames}
+			c.RemoveProductName(tt.remove)
+			if diff := cmp.Diff(tt.want, c.ProductNames); diff != "" {
+				t.Errorf("RemoveProductName() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompany_RemoveProductName_Update(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{}}`),
+	}
+	c := &hubspot.Company{ProductNames: hubspot.NewString("a")}
+	c.RemoveProductName("a")
+
+	if _, err := hubspot.NewMockClient(conf).CRM.Company.Update("company001", c); err != nil {
+		t.Fatalf("Update() unexpected error: %s", err)
+	}
+	if want := `"products":""`; !strings.Contains(string(conf.Requests[0].Body), want) {
+		t.Errorf("Update() body mismatch: want %s in %s", want, string(conf.Requests[0].Body))
+	}
+}
+
+func TestCompanyServiceOp_Get(t *testing.T) {
+	type args struct {
+		companyID string
+		company   interface{}
+		option    *hubspot.RequestQueryOption
+	}
+	tests := []struct {
+		name      string
+		conf      *hubspot.MockConfig
+		args      args
+		want      *hubspot.ResponseResource
+		wantPath  string
+		wantQuery url.Values
+		wantErr   error
+	}{
+		{
+			name: "Successfully get a company with inline associations",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(`{"id":"company001","properties":{"name":"Acme","hs_object_id":"company001"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false,"associations":{"contacts":{"results":[{"id":"contact001","type":"company_to_contact"}]},"deals":{"results":[{"id":"deal001","type":"company_to_deal"}]}}}`),
+			},
+			args: args{
+				companyID: "company001",
+				company:   &hubspot.Company{},
+				option: &hubspot.RequestQueryOption{
+					Associations: []string{"contacts", "deals"},
+				},
+			},
+			want: &hubspot.ResponseResource{
+				ID: "company001",
+				Associations: hubspot.Associations{
+					"contacts": {
+						Results: []hubspot.AssociationResult{
+							{ID: "contact001", Type: string(hubspot.AssociationTypeCompanyToContact)},
+						},
+					},
+					"deals": {
+						Results: []hubspot.AssociationResult{
+							{ID: "deal001", Type: string(hubspot.AssociationTypeCompanyToDeal)},
+						},
+					},
+				},
+				Properties: &hubspot.Company{
+					Name:       hubspot.NewString("Acme"),
+					HsObjectID: hubspot.NewString("company001"),
+				},
+				CreatedAt: &createdAt,
+				UpdatedAt: &updatedAt,
+			},
+			wantPath:  "/crm/v3/objects/companies/company001",
+			wantQuery: url.Values{"associations": []string{"contacts,deals"}},
+			wantErr:   nil,
+		},
+		{
+			name: "Successfully get a single association type",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(`{"id":"company001","properties":{"name":"Acme","hs_object_id":"company001"},"associations":{"contacts":{"results":[{"id":"contact001","type":"company_to_contact"}]}}}`),
+			},
+			args: args{
+				companyID: "company001",
+				company:   &hubspot.Company{},
+				option: &hubspot.RequestQueryOption{
+					Associations: []string{"contacts"},
+				},
+			},
+			want: &hubspot.ResponseResource{
+				ID: "company001",
+				Associations: hubspot.Associations{
+					"contacts": {
+						Results: []hubspot.AssociationResult{
+							{ID: "contact001", Type: string(hubspot.AssociationTypeCompanyToContact)},
+						},
+					},
+				},
+				AssociationResults: []hubspot.AssociationResult{
+					{ID: "contact001", Type: string(hubspot.AssociationTypeCompanyToContact)},
+				},
+				Properties: &hubspot.Company{
+					Name:       hubspot.NewString("Acme"),
+					HsObjectID: hubspot.NewString("company001"),
+				},
+			},
+			wantPath:  "/crm/v3/objects/companies/company001",
+			wantQuery: url.Values{"associations": []string{"contacts"}},
+			wantErr:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hubspot.NewMockClient(tt.conf).CRM.Company.Get(tt.args.companyID, tt.args.company, tt.args.option)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("Get() error mismatch: want %s got %s", tt.wantErr, err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpTimeOption); diff != "" {
+				t.Errorf("Get() response mismatch (-want +got):%s", diff)
+			}
+			req := tt.conf.Requests[0]
+			if req.URL.Path != tt.wantPath {
+				t.Errorf("Get() request path mismatch: want %s got %s", tt.wantPath, req.URL.Path)
+			}
+			for k, v := range tt.wantQuery {
+				if diff := cmp.Diff(v, req.URL.Query()[k]); diff != "" {
+					t.Errorf("Get() request query %s mismatch (-want +got):%s", k, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_Stream(t *testing.T) {
+	pages := map[string][]byte{
+		"":     []byte(`{"results":[{"id":"company001","properties":{"name":"Acme"}},{"id":"company002","properties":{"name":"Globex"}}],"paging":{"next":{"after":"2","link":"https://api.hubapi.com/crm/v3/objects/companies?after=2"}}}`),
+		"2":    []byte(`{"results":[{"id":"company003","properties":{"name":"Initech"}}]}`),
+		"fail": []byte(`{`),
+	}
+
+	t.Run("Successfully stream all pages", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages))
+		out, errc := c.CRM.Company.Stream(context.Background(), nil)
+
+		var got []string
+		for company := range out {
+			got = append(got, company.ID+":"+company.Properties.(*hubspot.Company).Name.String())
+		}
+		if err := <-errc; err != nil {
+			t.Fatalf("Stream() unexpected error: %s", err)
+		}
+		want := []string{"company001:Acme", "company002:Globex", "company003:Initech"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Stream() response mismatch (-want +got):%s", diff)
+		}
+	})
+
+	t.Run("Send error of a failed page", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages))
+		out, errc := c.CRM.Company.Stream(context.Background(), &hubspot.RequestQueryOption{After: "fail"})
+
+		for range out {
+			t.Error("Stream() unexpected company")
+		}
+		if err := <-errc; err == nil {
+			t.Error("Stream() error mismatch: want error got nil")
+		}
+	})
+
+	t.Run("Stop on context cancellation", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages))
+		ctx, cancel := context.WithCancel(context.Background())
+		out, errc := c.CRM.Company.Stream(ctx, nil)
+
+		<-out
+		cancel()
+		for range out {
+		}
+		if err := <-errc; !errors.Is(err, context.Canceled) {
+			t.Errorf("Stream() error mismatch: want %s got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestCompanyServiceOp_Upsert(t *testing.T) {
+	type CustomCompany struct {
+		hubspot.Company
+		ExternalID *hubspot.HsStr `json:"external_id,omitempty"`
+	}
+
+	tests := []struct {
+		name     string
+		conf     *hubspot.MockConfig
+		company  *CustomCompany
+		want     *hubspot.ResponseResource
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name: "Successfully upsert a company",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(`{"status":"COMPLETE","results":[{"id":"company001","properties":{"name":"Acme","external_id":"ext-1"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}]}`),
+			},
+			company: &CustomCompany{
+				Company:    hubspot.Company{Name: hubspot.NewString("Acme")},
+				ExternalID: hubspot.NewString("ext-1"),
+			},
+			want: &hubspot.ResponseResource{
+				ID: "company001",
+				Properties: &CustomCompany{
+					Company:    hubspot.Company{Name: hubspot.NewString("Acme")},
+					ExternalID: hubspot.NewString("ext-1"),
+				},
+				CreatedAt: &createdAt,
+				UpdatedAt: &updatedAt,
+			},
+			wantBody: `{"inputs":[{"idProperty":"external_id","id":"ext-1","properties":{"name":"Acme","external_id":"ext-1"}}]}`,
+		},
+		{
+			name:    "Failed because the id property is not set",
+			conf:    &hubspot.MockConfig{},
+			company: &CustomCompany{Company: hubspot.Company{Name: hubspot.NewString("Acme")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hubspot.NewMockClient(tt.conf).CRM.Company.Upsert(tt.company, "external_id")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Upsert() error mismatch: wantErr %v got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				if len(tt.conf.Requests) != 0 {
+					t.Error("Upsert() sent a request despite the error")
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpTimeOption); diff != "" {
+				t.Errorf("Upsert() response mismatch (-want +got):%s", diff)
+			}
+			req := tt.conf.Requests[0]
+			if want := "/crm/v3/objects/companies/batch/upsert"; req.URL.Path != want {
+				t.Errorf("Upsert() request path mismatch: want %s got %s", want, req.URL.Path)
+			}
+			if string(req.Body) != tt.wantBody {
+				t.Errorf("Upsert() request body mismatch: want %s got %s", tt.wantBody, string(req.Body))
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_GetMany(t *testing.T) {
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			id := path.Base(req.URL.Path)
+			if id == "missing" {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"error","message":"resource not found","category":"OBJECT_NOT_FOUND"}`)),
+					Header:     http.Header{},
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"` + id + `","properties":{"name":"name-` + id + `"}}`)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+
+	t.Run("Successfully get companies in order", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		ids := []string{"1", "2", "3", "4", "5", "6", "7"}
+		got, err := c.CRM.Company.GetMany(context.Background(), ids, 3, &hubspot.RequestQueryOption{})
+		if err != nil {
+			t.Fatalf("GetMany() unexpected error: %s", err)
+		}
+		for i, id := range ids {
+			if got[i].ID != id || got[i].Properties.(*hubspot.Company).Name.String() != "name-"+id {
+				t.Errorf("GetMany() result %d mismatch: want %s got %+v", i, id, got[i])
+			}
+		}
+	})
+
+	t.Run("Aggregate errors by index", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		got, err := c.CRM.Company.GetMany(context.Background(), []string{"1", "missing", "3"}, 0, &hubspot.RequestQueryOption{})
+		var multiErr hubspot.MultiError
+		if !errors.As(err, &multiErr) {
+			t.Fatalf("GetMany() error mismatch: want MultiError got %v", err)
+		}
+		if len(multiErr) != 1 || multiErr[0].Index != 1 {
+			t.Errorf("GetMany() error index mismatch: got %v", multiErr)
+		}
+		if got[0] == nil || got[1] != nil || got[2] == nil {
+			t.Errorf("GetMany() results mismatch: got %v", got)
+		}
+	})
+
+	t.Run("Stop on context cancellation", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.CRM.Company.GetMany(ctx, []string{"1", "2"}, 1, &hubspot.RequestQueryOption{})
+		var multiErr hubspot.MultiError
+		if !errors.As(err, &multiErr) || len(multiErr) != 2 || !errors.Is(multiErr[0], context.Canceled) {
+			t.Errorf("GetMany() error mismatch: want canceled errors got %v", err)
+		}
+	})
+}
+
+func TestCompanyServiceOp_BatchUpsert(t *testing.T) {
+	type CustomCompany struct {
+		hubspot.Company
+		ExternalID *hubspot.HsStr `json:"external_id,omitempty"`
+	}
+
+	conf := &hubspot.MockConfig{
+		Status: http.StatusMultiStatus,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"COMPLETE","results":[{"id":"company001","properties":{"external_id":"ext-1"},"new":true},{"id":"company002","properties":{"external_id":"ext-2"},"new":false}],"numErrors":1,"errors":[{"status":"error","category":"VALIDATION_ERROR","message":"Property values were not valid"}],"startedAt":"2019-10-30T03:30:17.883Z","completedAt":"2019-12-07T16:50:06.678Z"}`),
+	}
+	companies := []interface{}{
+		&CustomCompany{ExternalID: hubspot.NewString("ext-1")},
+		&CustomCompany{ExternalID: hubspot.NewString("ext-2")},
+		&CustomCompany{Company: hubspot.Company{Name: hubspot.NewString("Acme")}, ExternalID: hubspot.NewString("ext-3")},
+	}
+	want := &hubspot.BatchResponse{
+		Status: "COMPLETE",
+		Results: []hubspot.ResponseResource{
+			{ID: "company001", Properties: map[string]interface{}{"external_id": "ext-1"}, New: true},
+			{ID: "company002", Properties: map[string]interface{}{"external_id": "ext-2"}, New: false},
+		},
+		NumErrors: 1,
+		Errors: []hubspot.APIError{
+			{Status: "error", Category: hubspot.ValidationError, Message: "Property values were not valid"},
+		},
+		StartedAt:   &createdAt,
+		CompletedAt: &updatedAt,
+	}
+	wantBody := `{"inputs":[{"idProperty":"external_id","id":"ext-1","properties":{"external_id":"ext-1"}},{"idProperty":"external_id","id":"ext-2","properties":{"external_id":"ext-2"}},{"idProperty":"external_id","id":"ext-3","properties":{"name":"Acme","external_id":"ext-3"}}]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.BatchUpsert(companies, "external_id")
+	if err != nil {
+		t.Fatalf("BatchUpsert() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("BatchUpsert() response mismatch (-want +got):%s", diff)
+	}
+	if got := string(conf.Requests[0].Body); got != wantBody {
+		t.Errorf("BatchUpsert() request body mismatch: want %s got %s", wantBody, got)
+	}
+
+	if _, err := hubspot.NewMockClient(&hubspot.MockConfig{}).CRM.Company.BatchUpsert(make([]interface{}, 101), "external_id"); err == nil {
+		t.Error("BatchUpsert() error mismatch: want error for too many inputs got nil")
+	}
+}
+
+func TestCompanyServiceOp_BatchUpsert_WriteSource(t *testing.T) {
+	type CustomCompany struct {
+		hubspot.Company
+		ExternalID *hubspot.HsStr `json:"external_id,omitempty"`
+	}
+
+	conf := &hubspot.MockConfig{
+		Status: http.StatusMultiStatus,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"COMPLETE","results":[],"numErrors":1,"errors":[{"status":"error","category":"VALIDATION_ERROR","message":"Property values were not valid","context":{"objectWriteTraceId":["billing-sync:ext-1"]}}]}`),
+	}
+	c := hubspot.NewMockClient(conf)
+	if err := hubspot.WithWriteSource("billing-sync")(c); err != nil {
+		t.Fatalf("WithWriteSource() unexpected error: %s", err)
+	}
+	companies := []interface{}{&CustomCompany{ExternalID: hubspot.NewString("ext-1")}}
+	wantBody := `{"inputs":[{"idProperty":"external_id","id":"ext-1","objectWriteTraceId":"billing-sync:ext-1","properties":{"external_id":"ext-1"}}]}`
+
+	got, err := c.CRM.Company.BatchUpsert(companies, "external_id")
+	if err != nil {
+		t.Fatalf("BatchUpsert() unexpected error: %s", err)
+	}
+	if got := string(conf.Requests[0].Body); got != wantBody {
+		t.Errorf("BatchUpsert() request body mismatch: want %s got %s", wantBody, got)
+	}
+	want := []string{"billing-sync:ext-1"}
+	if len(got.Errors) != 1 {
+		t.Fatalf("BatchUpsert() errors mismatch: want 1 error got %v", got.Errors)
+	}
+	if diff := cmp.Diff(want, got.Errors[0].Context.ObjectWriteTraceID); diff != "" {
+		t.Errorf("BatchUpsert() objectWriteTraceId mismatch (-want +got):%s", diff)
+	}
+
+	if err := hubspot.WithWriteSource("")(c); err == nil {
+		t.Error("WithWriteSource() error mismatch: want error for empty source got nil")
+	}
+}
+
+func TestCompanyServiceOp_SearchMany(t *testing.T) {
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			var option hubspot.RequestSearchOption
+			if err := json.NewDecoder(req.Body).Decode(&option); err != nil || option.Query == "invalid" {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"error","message":"There was a problem with the request.","category":"VALIDATION_ERROR"}`)),
+					Header:     http.Header{},
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"total":1,"results":[{"id":"` + option.Query + `","properties":{"name":"name-` + option.Query + `"}}]}`)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	options := func(queries ...string) []*hubspot.RequestSearchOption {
+		opts := make([]*hubspot.RequestSearchOption, 0, len(queries))
+		for _, q := range queries {
+			opts = append(opts, &hubspot.RequestSearchOption{Query: q})
+		}
+		return opts
+	}
+
+	t.Run("Successfully search in order", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		queries := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+		got, err := c.CRM.Company.SearchMany(context.Background(), options(queries...), 3)
+		if err != nil {
+			t.Fatalf("SearchMany() unexpected error: %s", err)
+		}
+		for i, q := range queries {
+			if len(got[i].Results) != 1 || got[i].Results[0].ID != q || got[i].Results[0].Properties.(*hubspot.Company).Name.String() != "name-"+q {
+				t.Errorf("SearchMany() result %d mismatch: want %s got %+v", i, q, got[i])
+			}
+		}
+	})
+
+	t.Run("Aggregate errors by index", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		got, err := c.CRM.Company.SearchMany(context.Background(), options("1", "invalid", "3"), 0)
+		var multiErr hubspot.MultiError
+		if !errors.As(err, &multiErr) {
+			t.Fatalf("SearchMany() error mismatch: want MultiError got %v", err)
+		}
+		if len(multiErr) != 1 || multiErr[0].Index != 1 {
+			t.Errorf("SearchMany() error index mismatch: got %v", multiErr)
+		}
+		if got[0] == nil || got[1] != nil || got[2] == nil {
+			t.Errorf("SearchMany() results mismatch: got %v", got)
+		}
+	})
+
+	t.Run("Stop on context cancellation", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.CRM.Company.SearchMany(ctx, options("1", "2"), 1)
+		var multiErr hubspot.MultiError
+		if !errors.As(err, &multiErr) || len(multiErr) != 2 || !errors.Is(multiErr[0], context.Canceled) {
+			t.Errorf("SearchMany() error mismatch: want canceled errors got %v", err)
+		}
+	})
+}
+
+func TestCompanyServiceOp_SearchDeep_ExpectResults(t *testing.T) {
+	backoff := *hubspot.ExportSearchIndexBackoff
+	*hubspot.ExportSearchIndexBackoff = time.Millisecond
+	defer func() { *hubspot.ExportSearchIndexBackoff = backoff }()
+
+	responses := []string{
+		`{"total":0,"results":[]}`,
+		`{"total":1,"results":[{"id":"101","properties":{"name":"Acme"}}],"paging":{"next":{"after":"1"}}}`,
+		`{"total":0,"results":[]}`,
+	}
+	calls := 0
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(responses[calls-1])),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	option := &hubspot.RequestSearchOption{Properties: []string{"name"}, ExpectResults: true}
+
+	pager := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.SearchDeep(option)
+	var got []string
+	for pager.HasNext() {
+		companies, err := pager.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %s", err)
+		}
+		for _, company := range companies {
+			got = append(got, company.ID)
+		}
+	}
+	if diff := cmp.Diff([]string{"101"}, got); diff != "" {
+		t.Errorf("SearchDeep() response mismatch (-want +got):%s", diff)
+	}
+	if calls != 3 {
+		t.Errorf("SearchDeep() requests mismatch: want 3 with one retry of the first page got %d", calls)
+	}
+}
+
+func TestCompanyServiceOp_Search_ExpectResults(t *testing.T) {
+	backoff := *hubspot.ExportSearchIndexBackoff
+	*hubspot.ExportSearchIndexBackoff = time.Millisecond
+	defer func() { *hubspot.ExportSearchIndexBackoff = backoff }()
+
+	newHTTPClient := func(indexedAfter int, calls *int) *http.Client {
+		return &http.Client{
+			Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+				*calls++
+				body := `{"total":0,"results":[]}`
+				if *calls > indexedAfter {
+					body = `{"total":1,"results":[{"id":"company001","properties":{"name":"Acme"}}]}`
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+					Header:     http.Header{},
+				}
+			}),
+		}
+	}
+	tests := []struct {
+		name         string
+		indexedAfter int
+		option       *hubspot.RequestSearchOption
+		wantResults  int
+		wantCalls    int
+		wantErr      error
+	}{
+		{
+			name:         "Retry until indexed",
+			indexedAfter: 2,
+			option:       &hubspot.RequestSearchOption{Query: "Acme", ExpectResults: true},
+			wantResults:  1,
+			wantCalls:    3,
+		},
+		{
+			name:         "Failed when never indexed",
+			indexedAfter: 10,
+			option:       &hubspot.RequestSearchOption{Query: "Acme", ExpectResults: true},
+			wantCalls:    4,
+			wantErr:      hubspot.ErrSearchNotIndexed,
+		},
+		{
+			name:         "No retry without ExpectResults",
+			indexedAfter: 2,
+			option:       &hubspot.RequestSearchOption{Query: "Acme"},
+			wantCalls:    1,
+		},
+		{
+			name:         "No retry of the next pages",
+			indexedAfter: 2,
+			option:       &hubspot.RequestSearchOption{Query: "Acme", After: "100", ExpectResults: true},
+			wantCalls:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			c := hubspot.NewMockClientWithHTTPClient(newHTTPClient(tt.indexedAfter, &calls))
+			got, err := c.CRM.Company.Search(&hubspot.Company{}, tt.option)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Search() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+			if err == nil && len(got.Results) != tt.wantResults {
+				t.Errorf("Search() results mismatch: want %d got %d", tt.wantResults, len(got.Results))
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Search() requests mismatch: want %d got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_Get_NotFound(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusNotFound,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"error","message":"Object not found.  objectId are usually numeric.","correlationId":"5a479d9a-d0b9-4e0f-bcd7-f3fb878b83a6","category":"OBJECT_NOT_FOUND"}`),
+	}
+	_, err := hubspot.NewMockClient(conf).CRM.Company.Get("missing", &hubspot.Company{}, &hubspot.RequestQueryOption{})
+	if !errors.Is(err, hubspot.ErrNotFound) {
+		t.Errorf("Get() error mismatch: want ErrNotFound got %v", err)
+	}
+}
+
+func TestCompanyServiceOp_NilOption(t *testing.T) {
+	wantProperties := strings.Join(hubspot.ExportDefaultCompanyFields, ",")
+
+	t.Run("Get", func(t *testing.T) {
+		conf := &hubspot.MockConfig{
+			Status: http.StatusOK,
+			Header: http.Header{},
+			Body:   []byte(`{"id":"company001","properties":{"name":"Acme"}}`),
+		}
+		got, err := hubspot.NewMockClient(conf).CRM.Company.Get("company001", &hubspot.Company{}, nil)
+		if err != nil {
+			t.Fatalf("Get() unexpected error: %s", err)
+		}
+		want := &hubspot.ResponseResource{ID: "company001", Properties: &hubspot.Company{Name: hubspot.NewString("Acme")}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Get() response mismatch (-want +got):%s", diff)
+		}
+		if got := conf.Requests[0].URL.Query().Get("properties"); got != wantProperties {
+			t.Errorf("Get() properties mismatch: want %s got %s", wantProperties, got)
+		}
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		conf := &hubspot.MockConfig{
+			Status: http.StatusOK,
+			Header: http.Header{},
+			Body:   []byte(`{"results":[]}`),
+		}
+		if _, err := hubspot.NewMockClient(conf).CRM.Company.GetAll(&hubspot.Company{}, nil); err != nil {
+			t.Fatalf("GetAll() unexpected error: %s", err)
+		}
+		if got := conf.Requests[0].URL.Query().Get("properties"); got != wantProperties {
+			t.Errorf("GetAll() properties mismatch: want %s got %s", wantProperties, got)
+		}
+	})
+}
+
+func TestCompanyServiceOp_Get_NoDefaultProperties(t *testing.T) {
+	tests := []struct {
+		name   string
+		option *hubspot.RequestQueryOption
+		want   []string
+	}{
+		{
+			name:   "Only the given properties",
+			option: &hubspot.RequestQueryOption{Properties: []string{"name"}, NoDefaultProperties: true},
+			want:   []string{"name"},
+		},
+		{
+			name:   "No properties",
+			option: &hubspot.RequestQueryOption{NoDefaultProperties: true},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(`{"id":"company001","properties":{"name":"Acme"}}`),
+			}
+			if _, err := hubspot.NewMockClient(conf).CRM.Company.Get("company001", &hubspot.Company{}, tt.option); err != nil {
+				t.Fatalf("Get() unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tt.want, conf.Requests[0].URL.Query()["properties"]); diff != "" {
+				t.Errorf("Get() properties mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_RecentlyModified(t *testing.T) {
+	since := time.Date(2019, 10, 30, 0, 0, 0, 0, time.UTC)
+	// The first query reaches the 10,000 results cap, so the second query restarts from the modification time of company002.
+	responses := []string{
+		`{"results":[{"id":"company001","properties":{"name":"Acme"},"updatedAt":"2019-10-30T03:30:17.883Z"},{"id":"company002","properties":{"name":"Globex"},"updatedAt":"2019-12-07T16:50:06.678Z"}],"paging":{"next":{"after":"9950"}}}`,
+		`{"results":[{"id":"company002","properties":{"name":"Globex"},"updatedAt":"2019-12-07T16:50:06.678Z"},{"id":"company003","properties":{"name":"Initech"},"updatedAt":"2019-12-08T00:00:00.000Z"}]}`,
+	}
+	var bodies []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(responses[len(bodies)-1])),
+				Header:     http.Header{},
+			}
+		}),
+	}
+
+	pager := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.RecentlyModified(since, &hubspot.RequestQueryOption{Properties: []string{"name"}})
+	var got []string
+	for pager.HasNext() {
+		companies, err := pager.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %s", err)
+		}
+		for _, company := range companies {
+			got = append(got, company.ID+":"+company.Properties.(*hubspot.Company).Name.String())
+		}
+	}
+
+	want := []string{"company001:Acme", "company002:Globex", "company003:Initech"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RecentlyModified() response mismatch (-want +got):%s", diff)
+	}
+	wantBodies := []string{
+		`{"filterGroups":[{"filters":[{"value":"1572393600000","propertyName":"hs_lastmodifieddate","operator":"GTE"}]}],"sorts":[{"propertyName":"hs_lastmodifieddate","direction":"ASCENDING"}],"properties":["name"],"limit":200}`,
+		`{"filterGroups":[{"filters":[{"value":"1575737406678","propertyName":"hs_lastmodifieddate","operator":"GTE"}]}],"sorts":[{"propertyName":"hs_lastmodifieddate","direction":"ASCENDING"}],"properties":["name"],"limit":200}`,
+	}
+	if diff := cmp.Diff(wantBodies, bodies); diff != "" {
+		t.Errorf("RecentlyModified() request body mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_SearchPagers_Limit(t *testing.T) {
+	var bodies []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"total":0,"results":[]}`)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	c := hubspot.NewMockClientWithHTTPClient(httpClient)
+	since := time.Date(2019, 10, 30, 0, 0, 0, 0, time.UTC)
+
+	if _, err := c.CRM.Company.RecentlyModified(since, &hubspot.RequestQueryOption{Limit: 150}).Next(context.Background()); err != nil {
+		t.Fatalf("RecentlyModified() unexpected error: %s", err)
+	}
+	if _, err := c.CRM.Company.SearchDeep(&hubspot.RequestSearchOption{Limit: 150}).Next(context.Background()); err != nil {
+		t.Fatalf("SearchDeep() unexpected error: %s", err)
+	}
+	for _, body := range bodies {
+		if !strings.Contains(body, `"limit":150`) {
+			t.Errorf("request body mismatch: want limit 150 in %s", body)
+		}
+	}
+
+	bodies = nil
+	if _, err := c.CRM.Company.RecentlyModified(since, &hubspot.RequestQueryOption{Limit: 250}).Next(context.Background()); !errors.Is(err, hubspot.ErrInvalidLimit) {
+		t.Errorf("RecentlyModified() error mismatch: want ErrInvalidLimit got %v", err)
+	}
+	if _, err := c.CRM.Company.SearchDeep(&hubspot.RequestSearchOption{Limit: 250}).Next(context.Background()); !errors.Is(err, hubspot.ErrInvalidLimit) {
+		t.Errorf("SearchDeep() error mismatch: want ErrInvalidLimit got %v", err)
+	}
+	if len(bodies) != 0 {
+		t.Errorf("requests mismatch: want no request for an invalid limit got %d", len(bodies))
+	}
+}
+
+func TestCompanyServiceOp_CreateWithAssociations(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"name":"Acme","hs_object_id":"company001"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`),
+	}
+	associations := []hubspot.CreateAssociation{
+		{
+			To: hubspot.AssociationTo{ID: "contact001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDCompanyToContact},
+			},
+		},
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "company001",
+		Properties: &hubspot.Company{Name: hubspot.NewString("Acme"), HsObjectID: hubspot.NewString("company001")},
+		CreatedAt:  &createdAt,
+		UpdatedAt:  &updatedAt,
+	}
+	wantBody := `{"properties":{"name":"Acme"},"associations":[{"to":{"id":"contact001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":280}]}]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.CreateWithAssociations(&hubspot.Company{Name: hubspot.NewString("Acme")}, associations)
+	if err != nil {
+		t.Fatalf("CreateWithAssociations() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("CreateWithAssociations() response mismatch (-want +got):%s", diff)
+	}
+	if got := string(conf.Requests[0].Body); got != wantBody {
+		t.Errorf("CreateWithAssociations() request body mismatch: want %s got %s", wantBody, got)
+	}
+}
+
+func TestCompanyServiceOp_Create_WithoutHsObjectID(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"name":"Acme","hs_object_id":"company001"},"archived":false}`),
+	}
+	type company struct {
+		Name *hubspot.HsStr `json:"name,omitempty"`
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "company001",
+		Properties: &company{Name: hubspot.NewString("Acme")},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.Create(&company{Name: hubspot.NewString("Acme")})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Create() response mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_GetAll_Empty(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "Empty results",
+			body: `{"results":[]}`,
+		},
+		{
+			name: "No results",
+			body: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(tt.body),
+			}
+			got, err := hubspot.NewMockClient(conf).CRM.Company.GetAll(&hubspot.Company{}, nil)
+			if err != nil {
+				t.Fatalf("GetAll() unexpected error: %s", err)
+			}
+			want := &hubspot.ResponseResourceMulti{Results: []hubspot.ResponseResource{}}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+			}
+			if got.Results == nil {
+				t.Error("GetAll() results mismatch: want empty slice got nil")
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_GetAll_Archived(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"id":"company001","properties":{"name":"Acme"},"archived":true,"archivedAt":"2020-01-01T00:00:00.000Z"},{"id":"company002","properties":{"name":"Globex"},"archived":false}]}`),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{
+			{ID: "company001", Properties: map[string]interface{}{"name": "Acme"}, Archived: true, ArchivedAt: &archivedAt},
+			{ID: "company002", Properties: map[string]interface{}{"name": "Globex"}},
+		},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.GetAll(&hubspot.Company{}, &hubspot.RequestQueryOption{Archived: true})
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+	if want := "true"; conf.Requests[0].URL.Query().Get("archived") != want {
+		t.Errorf("GetAll() archived mismatch: want %s got %s", want, conf.Requests[0].URL.Query().Get("archived"))
+	}
+}
+
+func TestCompanyServiceOp_EnvelopeTimestamps(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"name":"Acme"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}`),
+	}
+	option := &hubspot.RequestQueryOption{Properties: []string{"name"}}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.Get("company001", &hubspot.Company{}, option)
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(&createdAt, got.CreatedAt, cmpTimeOption); diff != "" {
+		t.Errorf("Get() CreatedAt mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff(&updatedAt, got.UpdatedAt, cmpTimeOption); diff != "" {
+		t.Errorf("Get() UpdatedAt mismatch (-want +got):%s", diff)
+	}
+
+	conf.Body = []byte(`{"results":[` + string(conf.Body) + `]}`)
+	all, err := hubspot.NewMockClient(conf).CRM.Company.GetAll(&hubspot.Company{}, option)
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if len(all.Results) != 1 {
+		t.Fatalf("GetAll() results mismatch: want 1 got %d", len(all.Results))
+	}
+	if diff := cmp.Diff(&createdAt, all.Results[0].CreatedAt, cmpTimeOption); diff != "" {
+		t.Errorf("GetAll() CreatedAt mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff(&updatedAt, all.Results[0].UpdatedAt, cmpTimeOption); diff != "" {
+		t.Errorf("GetAll() UpdatedAt mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_EnvelopeFields(t *testing.T) {
+	type mappedCompany struct {
+		TrialStatus *hubspot.HsStr `json:"trialStatus,omitempty" hubspot:"trial_status,omitempty"`
+	}
+	object := `{"id":"company001","properties":{"trial_status":"ACTIVE"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":true,"archivedAt":"2019-12-07T16:50:06.678Z"}`
+	tests := []struct {
+		name string
+		body string
+		call func(c *hubspot.Client) (*hubspot.ResponseResource, error)
+	}{
+		{
+			name: "Search",
+			body: `{"total":1,"results":[` + object + `]}`,
+			call: func(c *hubspot.Client) (*hubspot.ResponseResource, error) {
+				res, err := c.CRM.Company.Search(&mappedCompany{}, &hubspot.RequestSearchOption{Properties: []string{"trial_status"}})
+				if err != nil || len(res.Results) != 1 {
+					return nil, fmt.Errorf("results %v: %v", res, err)
+				}
+				return &res.Results[0], nil
+			},
+		},
+		{
+			name: "BatchRead",
+			body: `{"status":"COMPLETE","results":[` + object + `]}`,
+			call: func(c *hubspot.Client) (*hubspot.ResponseResource, error) {
+				res, err := c.CRM.Company.BatchRead(&mappedCompany{}, []string{"company001"}, &hubspot.RequestQueryOption{Properties: []string{"trial_status"}})
+				if err != nil || len(res.Results) != 1 {
+					return nil, fmt.Errorf("results %v: %v", res, err)
+				}
+				return &res.Results[0], nil
+			},
+		},
+		{
+			name: "Get with properties mapped by the hubspot tag",
+			body: object,
+			call: func(c *hubspot.Client) (*hubspot.ResponseResource, error) {
+				return c.CRM.Company.Get("company001", &mappedCompany{}, &hubspot.RequestQueryOption{Properties: []string{"trial_status"}})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: []byte(tt.body)}
+			got, err := tt.call(hubspot.NewMockClient(conf))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := &hubspot.ResponseResource{
+				ID:         "company001",
+				Archived:   true,
+				Properties: &mappedCompany{TrialStatus: hubspot.NewString("ACTIVE")},
+				CreatedAt:  &createdAt,
+				UpdatedAt:  &updatedAt,
+				ArchivedAt: &updatedAt,
+			}
+			if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+				t.Errorf("response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_Get_PropertiesWithHistory(t *testing.T) {
+	history := make([]string, 12)
+	for i := range history {
+		history[i] = "prop" + strconv.Itoa(i)
+	}
+	var queries []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			q := req.URL.Query()
+			queries = append(queries, q.Get("propertiesWithHistory"))
+			body := `{"id":"company001","properties":{"name":"Acme"},"propertiesWithHistory":{"prop0":[{"value":"a","timestamp":"2019-10-30T03:30:17.883Z","sourceType":"CRM_UI","sourceId":"userId:1"}]}}`
+			if len(queries) > 1 {
+				body = `{"id":"company001","properties":{"hs_object_id":"company001"},"propertiesWithHistory":{"prop11":[{"value":"b","timestamp":"2019-12-07T16:50:06.678Z","sourceType":"API","sourceId":null,"sourceLabel":null}]}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "company001",
+		Properties: &hubspot.Company{Name: hubspot.NewString("Acme")},
+		PropertiesWithHistory: map[string][]hubspot.PropertyHistory{
+			"prop0":  {{Value: "a", Timestamp: &createdAt, SourceType: "CRM_UI", SourceID: "userId:1"}},
+			"prop11": {{Value: "b", Timestamp: &updatedAt, SourceType: "API"}},
+		},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.Get("company001", &hubspot.Company{}, &hubspot.RequestQueryOption{PropertiesWithHistory: history})
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	wantQueries := []string{strings.Join(history[:10], ","), strings.Join(history[10:], ",")}
+	if diff := cmp.Diff(wantQueries, queries); diff != "" {
+		t.Errorf("Get() propertiesWithHistory mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_ClearProperty(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"trial_end_date":""}}`),
+	}
+	c := hubspot.NewMockClient(conf)
+
+	if err := c.CRM.Company.ClearProperty("company001", "trial_end_date"); err != nil {
+		t.Fatalf("ClearProperty() unexpected error: %s", err)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/companies/company001"; req.Method != http.MethodPatch || req.URL.Path != want {
+		t.Errorf("ClearProperty() request mismatch: want PATCH %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	if want := `{"properties":{"trial_end_date":""}}`; string(req.Body) != want {
+		t.Errorf("ClearProperty() request body mismatch: want %s got %s", want, string(req.Body))
+	}
+
+	if err := c.CRM.Company.ClearProperty("company001", ""); err == nil {
+		t.Error("ClearProperty() error mismatch: want error for an empty property name got nil")
+	}
+	if len(conf.Requests) != 1 {
+		t.Errorf("ClearProperty() requests mismatch: want 1 got %d", len(conf.Requests))
+	}
+}
+
+func TestCompanyServiceOp_SearchDeep(t *testing.T) {
+	responses := []string{
+		`{"results":[{"id":"101","properties":{"name":"Acme"}},{"id":"102","properties":{"name":"Globex"}}],"paging":{"next":{"after":"2"}}}`,
+		`{"results":[{"id":"103","properties":{"name":"Initech"}}]}`,
+	}
+	var bodies []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(responses[len(bodies)-1])),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	option := (&hubspot.RequestSearchOption{Properties: []string{"name"}, Limit: 2}).
+		AddFilterGroup(hubspot.Filter{PropertyName: "trial_status", Operator: hubspot.FilterOperatorEqual, Value: "active"})
+
+	pager := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.SearchDeep(option)
+	var got []string
+	for pager.HasNext() {
+		companies, err := pager.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %s", err)
+		}
+		for _, company := range companies {
+			got = append(got, company.ID+":"+company.Properties.(*hubspot.Company).Name.String())
+		}
+	}
+
+	want := []string{"101:Acme", "102:Globex", "103:Initech"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SearchDeep() response mismatch (-want +got):%s", diff)
+	}
+	wantBodies := []string{
+		`{"filterGroups":[{"filters":[{"value":"active","propertyName":"trial_status","operator":"EQ"}]}],"sorts":[{"propertyName":"hs_object_id","direction":"ASCENDING"}],"properties":["name"],"limit":2}`,
+		`{"filterGroups":[{"filters":[{"value":"active","propertyName":"trial_status","operator":"EQ"},{"value":"102","propertyName":"hs_object_id","operator":"GT"}]}],"sorts":[{"propertyName":"hs_object_id","direction":"ASCENDING"}],"properties":["name"],"limit":2}`,
+	}
+	if diff := cmp.Diff(wantBodies, bodies); diff != "" {
+		t.Errorf("SearchDeep() request body mismatch (-want +got):%s", diff)
+	}
+	if len(option.FilterGroups[0].Filters) != 1 {
+		t.Errorf("SearchDeep() changed the filters of the option: %v", option.FilterGroups)
+	}
+
+	sorted := (&hubspot.RequestSearchOption{}).AddSort("name", hubspot.SortDirectionAscending)
+	if _, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.SearchDeep(sorted).Next(context.Background()); err == nil {
+		t.Error("Next() error mismatch: want error for a sort other than hs_object_id got nil")
+	}
+
+	full := (&hubspot.RequestSearchOption{}).AddFilterGroup(
+		hubspot.FilterHasProperty("name"), hubspot.FilterHasProperty("domain"), hubspot.FilterHasProperty("phone"),
+		hubspot.FilterHasProperty("city"), hubspot.FilterHasProperty("state"), hubspot.FilterHasProperty("country"),
+	)
+	bodies = nil
+	if _, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.SearchDeep(full).Next(context.Background()); err == nil {
+		t.Error("Next() error mismatch: want error for a filter group without room for the ID filter got nil")
+	}
+	if len(bodies) != 0 {
+		t.Errorf("Next() requests mismatch: want no request got %d", len(bodies))
+	}
+}
+
+func TestCompanyServiceOp_GetAll_InvalidLimit(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[]}`),
+	}
+	c := hubspot.NewMockClient(conf)
+
+	_, err := c.CRM.Company.GetAll(&hubspot.Company{}, &hubspot.RequestQueryOption{Limit: 1000})
+	if !errors.Is(err, hubspot.ErrInvalidLimit) {
+		t.Errorf("GetAll() error mismatch: want ErrInvalidLimit got %v", err)
+	}
+	if len(conf.Requests) != 0 {
+		t.Errorf("GetAll() requests mismatch: want no request got %d", len(conf.Requests))
+	}
+
+	if _, err := c.CRM.Company.GetAll(&hubspot.Company{}, &hubspot.RequestQueryOption{Limit: 100}); err != nil {
+		t.Errorf("GetAll() unexpected error: %s", err)
+	}
+}
+
+func TestCompanyServiceOp_Search(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"total":3,"results":[{"id":"101","properties":{"name":"Acme"}},{"id":"102","properties":{"name":"Globex"}}],"paging":{"next":{"after":"2"}}}`),
+	}
+	company := &hubspot.Company{}
+	want := &hubspot.ResponseResourceMulti{
+		Total: 3,
+		Results: []hubspot.ResponseResource{
+			{ID: "101", Properties: &hubspot.Company{Name: hubspot.NewString("Acme")}},
+			{ID: "102", Properties: &hubspot.Company{Name: hubspot.NewString("Globex")}},
+		},
+		Paging: &hubspot.Paging{Next: &hubspot.PagingNext{After: "2"}},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.Search(company, &hubspot.RequestSearchOption{Properties: []string{"name"}, Limit: 2})
+	if err != nil {
+		t.Fatalf("Search() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Search() response mismatch (-want +got):%s", diff)
+	}
+	if company.Name.String() != "Acme" {
+		t.Errorf("Search() binding mismatch: want the first result bound to the argument got %s", company.Name.String())
+	}
+}
+
+func TestCompanyServiceOp_Search_Query(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"total":1,"results":[{"id":"101","properties":{"name":"Acme"}}]}`),
+	}
+	option := (&hubspot.RequestSearchOption{Properties: []string{"name"}}).
+		WithQuery("acm").
+		AddFilterGroup(hubspot.Filter{PropertyName: "industry", Operator: hubspot.FilterOperatorEqual, Value: "SOFTWARE"})
+	wantBody := `{"query":"acm","filterGroups":[{"filters":[{"value":"SOFTWARE","propertyName":"industry","operator":"EQ"}]}],"properties":["name"]}`
+
+	if _, err := hubspot.NewMockClient(conf).CRM.Company.Search(&hubspot.Company{}, option); err != nil {
+		t.Fatalf("Search() unexpected error: %s", err)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/companies/search"; req.Method != http.MethodPost || req.URL.Path != want {
+		t.Errorf("Search() request mismatch: want POST %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	if string(req.Body) != wantBody {
+		t.Errorf("Search() request body mismatch: want %s got %s", wantBody, string(req.Body))
+	}
+}
+
+func TestCompanyServiceOp_Search_Paging(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"total":300,"results":[{"id":"301","properties":{"name":"Acme"}}],"paging":{"next":{"after":"400"}}}`),
+	}
+	c := hubspot.NewMockClient(conf)
+
+	got, err := c.CRM.Company.Search(&hubspot.Company{}, &hubspot.RequestSearchOption{Properties: []string{"name"}, Limit: 200, After: "200"})
+	if err != nil {
+		t.Fatalf("Search() unexpected error: %s", err)
+	}
+	if want := `{"properties":["name"],"limit":200,"after":"200"}`; string(conf.Requests[0].Body) != want {
+		t.Errorf("Search() request body mismatch: want %s got %s", want, string(conf.Requests[0].Body))
+	}
+	if got.Paging == nil || got.Paging.Next == nil || got.Paging.Next.After != "400" {
+		t.Errorf("Search() paging mismatch: want the cursor 400 got %+v", got.Paging)
+	}
+
+	_, err = c.CRM.Company.Search(&hubspot.Company{}, &hubspot.RequestSearchOption{Limit: 201})
+	if !errors.Is(err, hubspot.ErrInvalidLimit) {
+		t.Errorf("Search() error mismatch: want ErrInvalidLimit got %v", err)
+	}
+	if len(conf.Requests) != 1 {
+		t.Errorf("Search() requests mismatch: want no request for the invalid limit got %d", len(conf.Requests)-1)
+	}
+}
+
+func TestCompanyServiceOp_UpdateFieldIfEmpty(t *testing.T) {
+	t.Run("Update an empty property", func(t *testing.T) {
+		conf := &hubspot.MockConfig{
+			Status: http.StatusOK,
+			Header: http.Header{},
+			Body:   []byte(`{"id":"company001","properties":{"name":"Acme","sales_notes":null}}`),
+		}
+		if err := hubspot.NewMockClient(conf).CRM.Company.UpdateFieldIfEmpty("company001", "sales_notes", hubspot.NewString("Call back in May")); err != nil {
+			t.Fatalf("UpdateFieldIfEmpty() unexpected error: %s", err)
+		}
+		if len(conf.Requests) != 2 {
+			t.Fatalf("UpdateFieldIfEmpty() requests mismatch: want 2 got %d", len(conf.Requests))
+		}
+		if got := conf.Requests[0].URL.Query().Get("properties"); !strings.HasSuffix(got, ",sales_notes") {
+			t.Errorf("UpdateFieldIfEmpty() read properties mismatch: want sales_notes in %s", got)
+		}
+		req := conf.Requests[1]
+		if req.Method != http.MethodPatch {
+			t.Errorf("UpdateFieldIfEmpty() request method mismatch: want PATCH got %s", req.Method)
+		}
+		if want := `{"properties":{"sales_notes":"Call back in May"}}`; string(req.Body) != want {
+			t.Errorf("UpdateFieldIfEmpty() request body mismatch: want %s got %s", want, string(req.Body))
+		}
+	})
+
+	t.Run("Refuse to overwrite a set property", func(t *testing.T) {
+		conf := &hubspot.MockConfig{
+			Status: http.StatusOK,
+			Header: http.Header{},
+			Body:   []byte(`{"id":"company001","properties":{"name":"Acme","sales_notes":"Entered by sales"}}`),
+		}
+		err := hubspot.NewMockClient(conf).CRM.Company.UpdateFieldIfEmpty("company001", "sales_notes", "Call back in May")
+		if !errors.Is(err, hubspot.ErrFieldAlreadySet) {
+			t.Errorf("UpdateFieldIfEmpty() error mismatch: want ErrFieldAlreadySet got %v", err)
+		}
+		if len(conf.Requests) != 1 {
+			t.Errorf("UpdateFieldIfEmpty() requests mismatch: want only the read got %d", len(conf.Requests))
+		}
+	})
+}
+
+func TestCompanyServiceOp_PropertiesEnvelope(t *testing.T) {
+	body := []byte(`{"id":"company001","properties":{"name":"Acme","domain":"acme.com","hs_object_id":"company001"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`)
+	want := &hubspot.ResponseResource{
+		ID:         "company001",
+		Properties: &hubspot.Company{Name: hubspot.NewString("Acme"), Domain: hubspot.NewString("acme.com"), HsObjectID: hubspot.NewString("company001")},
+		CreatedAt:  &createdAt,
+		UpdatedAt:  &updatedAt,
+	}
+	tests := []struct {
+		name     string
+		call     func(s hubspot.CompanyService) (*hubspot.ResponseResource, error)
+		wantBody string
+	}{
+		{
+			name: "Create",
+			call: func(s hubspot.CompanyService) (*hubspot.ResponseResource, error) {
+				return s.Create(&hubspot.Company{Name: hubspot.NewString("Acme"), Domain: hubspot.NewString("acme.com")})
+			},
+			wantBody: `{"properties":{"name":"Acme","domain":"acme.com"}}`,
+		},
+		{
+			name: "Update",
+			call: func(s hubspot.CompanyService) (*hubspot.ResponseResource, error) {
+				return s.Update("company001", &hubspot.Company{Domain: hubspot.NewString("acme.com")})
+			},
+			wantBody: `{"properties":{"domain":"acme.com"}}`,
+		},
+		{
+			name: "Get",
+			call: func(s hubspot.CompanyService) (*hubspot.ResponseResource, error) {
+				return s.Get("company001", &hubspot.Company{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: body}
+			got, err := tt.call(hubspot.NewMockClient(conf).CRM.Company)
+			if err != nil {
+				t.Fatalf("%s() unexpected error: %s", tt.name, err)
+			}
+			if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+				t.Errorf("%s() response mismatch (-want +got):%s", tt.name, diff)
+			}
+			if got := string(conf.Requests[0].Body); got != tt.wantBody {
+				t.Errorf("%s() request body mismatch: want %s got %s", tt.name, tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_BatchRead(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusMultiStatus,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"COMPLETE","results":[{"id":"company001","properties":{"name":"Acme"},"propertiesWithHistory":{"name":[{"value":"Acme","timestamp":"2019-12-07T16:50:06.678Z","sourceType":"CRM_UI"},{"value":"Acme Inc","timestamp":"2019-10-30T03:30:17.883Z","sourceType":"API"}]}}],"numErrors":1,"errors":[{"status":"error","category":"OBJECT_NOT_FOUND","message":"Could not get some COMPANY objects"}]}`),
+	}
+	want := &hubspot.BatchResponse{
+		Results: []hubspot.ResponseResource{
+			{
+				ID:         "company001",
+				Properties: &hubspot.Company{Name: hubspot.NewString("Acme")},
+				PropertiesWithHistory: map[string][]hubspot.PropertyHistory{
+					"name": {
+						{Value: "Acme", Timestamp: &updatedAt, SourceType: "CRM_UI"},
+						{Value: "Acme Inc", Timestamp: &createdAt, SourceType: "API"},
+					},
+				},
+			},
+		},
+		NumErrors: 1,
+		Errors:    []hubspot.APIError{{Status: "error", Category: "OBJECT_NOT_FOUND", Message: "Could not get some COMPANY objects"}},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.BatchRead(&hubspot.Company{}, []string{"company001", "company002"}, &hubspot.RequestQueryOption{PropertiesWithHistory: []string{"name"}})
+	if err != nil {
+		t.Fatalf("BatchRead() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("BatchRead() response mismatch (-want +got):%s", diff)
+	}
+
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/companies/batch/read"; req.Method != http.MethodPost || req.URL.Path != want {
+		t.Errorf("BatchRead() request mismatch: want POST %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	type batchReadBody struct {
+		Properties            []string            `json:"properties"`
+		PropertiesWithHistory []string            `json:"propertiesWithHistory"`
+		Inputs                []map[string]string `json:"inputs"`
+	}
+	body := batchReadBody{}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		t.Fatalf("BatchRead() request body unexpected error: %s", err)
+	}
+	wantBody := batchReadBody{
+		Properties:            hubspot.ExportDefaultCompanyFields,
+		PropertiesWithHistory: []string{"name"},
+		Inputs:                []map[string]string{{"id": "company001"}, {"id": "company002"}},
+	}
+	if diff := cmp.Diff(wantBody, body); diff != "" {
+		t.Errorf("BatchRead() request body mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_BatchRead_IDProperty(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"COMPLETE","results":[{"id":"company001","properties":{"name":"Acme","external_id":"ext-1"}},{"id":"company002","properties":{"name":"Globex","external_id":"ext-2"}}]}`),
+	}
+	type company struct {
+		Name       *hubspot.HsStr `json:"name,omitempty"`
+		ExternalID *hubspot.HsStr `json:"external_id,omitempty"`
+	}
+	want := &hubspot.BatchResponse{
+		Results: []hubspot.ResponseResource{
+			{ID: "company001", Properties: &company{Name: hubspot.NewString("Acme"), ExternalID: hubspot.NewString("ext-1")}},
+			{ID: "company002", Properties: &company{Name: hubspot.NewString("Globex"), ExternalID: hubspot.NewString("ext-2")}},
+		},
+	}
+
+	option := &hubspot.RequestQueryOption{CustomProperties: []string{"external_id"}, IDProperty: "external_id"}
+	got, err := hubspot.NewMockClient(conf).CRM.Company.BatchRead(&company{}, []string{"ext-1", "ext-2"}, option)
+	if err != nil {
+		t.Fatalf("BatchRead() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("BatchRead() response mismatch (-want +got):%s", diff)
+	}
+
+	type batchReadBody struct {
+		IDProperty string              `json:"idProperty"`
+		Inputs     []map[string]string `json:"inputs"`
+	}
+	body := batchReadBody{}
+	if err := json.Unmarshal(conf.Requests[0].Body, &body); err != nil {
+		t.Fatalf("BatchRead() request body unexpected error: %s", err)
+	}
+	wantBody := batchReadBody{IDProperty: "external_id", Inputs: []map[string]string{{"id": "ext-1"}, {"id": "ext-2"}}}
+	if diff := cmp.Diff(wantBody, body); diff != "" {
+		t.Errorf("BatchRead() request body mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_Get_LargeID(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"9007199254740993","properties":{"hs_object_id":9007199254740993,"name":"Acme"}}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "9007199254740993",
+		Properties: &hubspot.Company{HsObjectID: hubspot.NewString("9007199254740993"), Name: hubspot.NewString("Acme")},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.Get("9007199254740993", &hubspot.Company{}, nil)
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/objects/companies/9007199254740993"; conf.Requests[0].URL.Path != want {
+		t.Errorf("Get() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestCompanyServiceOp_Update_ClearString(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"name":"Acme","phone":""}}`),
+	}
+	company := &hubspot.Company{Phone: hubspot.ClearString()}
+
+	if _, err := hubspot.NewMockClient(conf).CRM.Company.Update("company001", company); err != nil {
+		t.Fatalf("Update() unexpected error: %s", err)
+	}
+	if want := `{"properties":{"phone":""}}`; string(conf.Requests[0].Body) != want {
+		t.Errorf("Update() request body mismatch: want %s got %s", want, string(conf.Requests[0].Body))
+	}
+	if !company.Phone.IsClear() {
+		t.Errorf("Update() response mismatch: want a cleared phone got %v", company.Phone)
+	}
+}
+
+func TestCompanyServiceOp_ListIDs(t *testing.T) {
+	pages := map[string][]byte{
+		"":           []byte(`{"results":[{"id":"company001","properties":{"hs_object_id":"company001"}},{"id":"company002","properties":{"hs_object_id":"company002"}}],"paging":{"next":{"after":"company002"}}}`),
+		"company002": []byte(`{"results":[{"id":"company003","properties":{"hs_object_id":"company003"}}]}`),
+	}
+	var queries []url.Values
+	pagesClient := hubspot.NewMockPagesHTTPClient(pages)
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			queries = append(queries, req.URL.Query())
+			resp, _ := pagesClient.Transport.RoundTrip(req)
+			return resp
+		}),
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.ListIDs(&hubspot.RequestQueryOption{CustomProperties: []string{"custom_a"}})
+	if err != nil {
+		t.Fatalf("ListIDs() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"company001", "company002", "company003"}, got); diff != "" {
+		t.Errorf("ListIDs() mismatch (-want +got):%s", diff)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("ListIDs() requests mismatch: want 2 got %d", len(queries))
+	}
+	for _, q := range queries {
+		if q.Get("properties") != "hs_object_id" || q.Get("limit") != "100" {
+			t.Errorf("ListIDs() query mismatch: want only hs_object_id with limit 100 got %v", q)
+		}
+	}
+}
+
+func TestCompanyServiceOp_BatchUpdate(t *testing.T) {
+	type CustomCompany struct {
+		hubspot.Company
+		ExternalID *hubspot.HsStr `json:"external_id,omitempty"`
+	}
+
+	tests := []struct {
+		name       string
+		companies  []interface{}
+		idProperty string
+		wantBody   string
+		wantErr    bool
+	}{
+		{
+			name: "Key on a unique property",
+			companies: []interface{}{
+				&CustomCompany{Company: hubspot.Company{Name: hubspot.NewString("Acme")}, ExternalID: hubspot.NewString("ext-1")},
+			},
+			idProperty: "external_id",
+			wantBody:   `{"inputs":[{"id":"ext-1","idProperty":"external_id","properties":{"name":"Acme","external_id":"ext-1"}}]}`,
+		},
+		{
+			name: "Key on the object ID",
+			companies: []interface{}{
+				&hubspot.Company{Name: hubspot.NewString("Acme"), HsObjectID: hubspot.NewString("company001")},
+			},
+			wantBody: `{"inputs":[{"id":"company001","properties":{"name":"Acme","hs_object_id":"company001"}}]}`,
+		},
+		{
+			name:       "Missing key",
+			companies:  []interface{}{&CustomCompany{Company: hubspot.Company{Name: hubspot.NewString("Acme")}}},
+			idProperty: "external_id",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(`{"status":"COMPLETE","results":[{"id":"company001","properties":{"name":"Acme"}}]}`),
+			}
+
+			got, err := hubspot.NewMockClient(conf).CRM.Company.BatchUpdate(tt.companies, tt.idProperty)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BatchUpdate() error mismatch: want error %t got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				if len(conf.Requests) != 0 {
+					t.Errorf("BatchUpdate() requests mismatch: want 0 got %d", len(conf.Requests))
+				}
+				return
+			}
+			if len(got.Results) != 1 || got.Results[0].ID != "company001" {
+				t.Errorf("BatchUpdate() results mismatch: got %+v", got.Results)
+			}
+			req := conf.Requests[0]
+			if want := "/crm/v3/objects/companies/batch/update"; req.Method != http.MethodPost || req.URL.Path != want {
+				t.Errorf("BatchUpdate() request mismatch: want POST %s got %s %s", want, req.Method, req.URL.Path)
+			}
+			if got := string(req.Body); got != tt.wantBody {
+				t.Errorf("BatchUpdate() request body mismatch: want %s got %s", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_BatchArchive(t *testing.T) {
+	var requests []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			requests = append(requests, req.URL.Path+" "+string(body))
+			if strings.HasSuffix(req.URL.Path, "/archive") {
+				return &http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(bytes.NewBufferString("")), Header: http.Header{}}
+			}
+			return &http.Response{
+				StatusCode: http.StatusMultiStatus,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"COMPLETE","results":[{"id":"company001","properties":{"hs_object_id":"company001"}}],"errors":[{"status":"error","category":"OBJECT_NOT_FOUND","message":"Could not get some COMPANY objects"}]}`)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+
+	t.Run("Archive by the object IDs", func(t *testing.T) {
+		requests = nil
+		if err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.BatchArchive([]string{"company001", "company002"}, nil); err != nil {
+			t.Fatalf("BatchArchive() unexpected error: %s", err)
+		}
+		want := []string{`/crm/v3/objects/companies/batch/archive {"inputs":[{"id":"company001"},{"id":"company002"}]}`}
+		if diff := cmp.Diff(want, requests); diff != "" {
+			t.Errorf("BatchArchive() requests mismatch (-want +got):%s", diff)
+		}
+	})
+
+	t.Run("Archive by a unique property", func(t *testing.T) {
+		requests = nil
+		if err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.BatchArchive([]string{"ext-1", "ext-missing"}, &hubspot.BatchArchiveOption{IDProperty: "external_id"}); err != nil {
+			t.Fatalf("BatchArchive() unexpected error: %s", err)
+		}
+		want := []string{
+			`/crm/v3/objects/companies/batch/read {"properties":["hs_object_id"],"idProperty":"external_id","inputs":[{"id":"ext-1"},{"id":"ext-missing"}]}`,
+			`/crm/v3/objects/companies/batch/archive {"inputs":[{"id":"company001"}]}`,
+		}
+		if diff := cmp.Diff(want, requests); diff != "" {
+			t.Errorf("BatchArchive() requests mismatch (-want +got):%s", diff)
+		}
+	})
+
+	ids := make([]string, 11)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("company%03d", i+1)
+	}
+
+	t.Run("Refuse more companies than the limit", func(t *testing.T) {
+		requests = nil
+		err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.BatchArchive(ids, nil)
+		if !errors.Is(err, hubspot.ErrArchiveNotConfirmed) {
+			t.Fatalf("BatchArchive() error mismatch: want ErrArchiveNotConfirmed got %v", err)
+		}
+		if len(requests) != 0 {
+			t.Errorf("BatchArchive() requests mismatch: want none got %v", requests)
+		}
+	})
+
+	t.Run("Archive more companies than the limit with Force", func(t *testing.T) {
+		requests = nil
+		if err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.BatchArchive(ids, &hubspot.BatchArchiveOption{Force: true}); err != nil {
+			t.Fatalf("BatchArchive() unexpected error: %s", err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("BatchArchive() requests mismatch: want 1 got %d", len(requests))
+		}
+	})
+
+	t.Run("Archive up to the limit set by WithBatchArchiveLimit", func(t *testing.T) {
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		if err := hubspot.WithBatchArchiveLimit(11)(c); err != nil {
+			t.Fatalf("WithBatchArchiveLimit() unexpected error: %s", err)
+		}
+		requests = nil
+		if err := c.CRM.Company.BatchArchive(ids, nil); err != nil {
+			t.Fatalf("BatchArchive() unexpected error: %s", err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("BatchArchive() requests mismatch: want 1 got %d", len(requests))
+		}
+		if err := hubspot.WithBatchArchiveLimit(1)(c); err != nil {
+			t.Fatalf("WithBatchArchiveLimit() unexpected error: %s", err)
+		}
+		if err := c.CRM.Company.BatchArchive([]string{"company001", "company002"}, nil); !errors.Is(err, hubspot.ErrArchiveNotConfirmed) {
+			t.Errorf("BatchArchive() error mismatch: want ErrArchiveNotConfirmed got %v", err)
+		}
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		if err := hubspot.WithBatchArchiveLimit(0)(hubspot.NewMockClient(&hubspot.MockConfig{})); err == nil {
+			t.Error("WithBatchArchiveLimit() error mismatch: want error got nil")
+		}
+	})
+}
+
+func TestCompanyServiceOp_SetPropertyForAll(t *testing.T) {
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	var inputs []int
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body := struct {
+				Inputs []struct {
+					ID         string            `json:"id"`
+					Properties map[string]string `json:"properties"`
+				} `json:"inputs"`
+			}{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			inputs = append(inputs, len(body.Inputs))
+			res := `{"status":"COMPLETE","results":[{"id":"` + body.Inputs[0].ID + `","properties":{"trial_status":"` + body.Inputs[0].Properties["trial_status"] + `"}}]`
+			if len(inputs) == 2 {
+				res += `,"numErrors":1,"errors":[{"status":"error","category":"OBJECT_NOT_FOUND","message":"Could not get some COMPANY objects","context":{"ids":["150"]}}]`
+			}
+			return &http.Response{
+				StatusCode: http.StatusMultiStatus,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(res + "}")),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{
+			{ID: "1", Properties: map[string]interface{}{"trial_status": "expired"}},
+			{ID: "101", Properties: map[string]interface{}{"trial_status": "expired"}},
+		},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.SetPropertyForAll(ids, "trial_status", "expired")
+	var batchErr *hubspot.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 {
+		t.Fatalf("SetPropertyForAll() error mismatch: want BatchError of 1 error got %v", err)
+	}
+	if diff := cmp.Diff([]string{"150"}, batchErr.Errors[0].Context.IDs); diff != "" {
+		t.Errorf("SetPropertyForAll() failed IDs mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SetPropertyForAll() response mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]int{100, 50}, inputs); diff != "" {
+		t.Errorf("SetPropertyForAll() batch sizes mismatch (-want +got):%s", diff)
+	}
+
+	failing := func(status int, requests *int) *http.Client {
+		return &http.Client{
+			Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+				*requests++
+				return &http.Response{
+					StatusCode: status,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":"error","message":"failed"}`)),
+					Header:     http.Header{},
+				}
+			}),
+		}
+	}
+
+	requests := 0
+	got, err = hubspot.NewMockClientWithHTTPClient(failing(http.StatusInternalServerError, &requests)).CRM.Company.SetPropertyForAll(ids, "trial_status", "expired")
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 {
+		t.Fatalf("SetPropertyForAll() error mismatch: want BatchError of 2 errors got %v", err)
+	}
+	if diff := cmp.Diff(ids[100:], batchErr.Errors[1].Context.IDs); diff != "" {
+		t.Errorf("SetPropertyForAll() failed batch IDs mismatch (-want +got):%s", diff)
+	}
+	if got == nil || len(got.Results) != 0 || requests != 2 {
+		t.Errorf("SetPropertyForAll() mismatch: want empty result after 2 requests got %v after %d", got, requests)
+	}
+
+	requests = 0
+	got, err = hubspot.NewMockClientWithHTTPClient(failing(http.StatusUnauthorized, &requests)).CRM.Company.SetPropertyForAll(ids, "trial_status", "expired")
+	if !errors.Is(err, hubspot.ErrUnauthorized) {
+		t.Errorf("SetPropertyForAll() error mismatch: want ErrUnauthorized got %v", err)
+	}
+	if got == nil || requests != 1 {
+		t.Errorf("SetPropertyForAll() mismatch: want a result after 1 request got %v after %d", got, requests)
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "acme.com", want: "acme.com"},
+		{domain: "https://Acme.com/", want: "acme.com"},
+		{domain: "http://www.acme.com", want: "acme.com"},
+		{domain: " WWW.Acme.COM/about?ref=1 ", want: "acme.com"},
+		{domain: "shop.acme.co.uk", want: "shop.acme.co.uk"},
+		{domain: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			if got := hubspot.NormalizeDomain(tt.domain); got != tt.want {
+				t.Errorf("NormalizeDomain() mismatch: want %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithDomainNormalization(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize bool
+		company   func() *hubspot.Company
+		wantBody  string
+	}{
+		{
+			name:      "Normalize the domain",
+			normalize: true,
+			company: func() *hubspot.Company {
+				return &hubspot.Company{Name: hubspot.NewString("Acme"), Domain: hubspot.NewString("https://www.Acme.com/")}
+			},
+			wantBody: `{"properties":{"domain":"acme.com","name":"Acme"}}`,
+		},
+		{
+			name:      "No domain",
+			normalize: true,
+			company:   func() *hubspot.Company { return &hubspot.Company{Name: hubspot.NewString("Acme")} },
+			wantBody:  `{"properties":{"name":"Acme"}}`,
+		},
+		{
+			name:     "Disabled",
+			company:  func() *hubspot.Company { return &hubspot.Company{Domain: hubspot.NewString("https://www.Acme.com/")} },
+			wantBody: `{"properties":{"domain":"https://www.Acme.com/"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{},
+				Body:   []byte(`{"id":"company001","properties":{"domain":"acme.com"}}`),
+			}
+			c := hubspot.NewMockClient(conf)
+			if tt.normalize {
+				if err := hubspot.WithDomainNormalization()(c); err != nil {
+					t.Fatalf("WithDomainNormalization() unexpected error: %s", err)
+				}
+			}
+
+			// The company is overwritten by the response, so each call is given a new one.
+			if _, err := c.CRM.Company.Create(tt.company()); err != nil {
+				t.Fatalf("Create() unexpected error: %s", err)
+			}
+			if _, err := c.CRM.Company.Update("company001", tt.company()); err != nil {
+				t.Fatalf("Update() unexpected error: %s", err)
+			}
+			for _, req := range conf.Requests {
+				if got := string(req.Body); got != tt.wantBody {
+					t.Errorf("%s request body mismatch: want %s got %s", req.Method, tt.wantBody, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCompanyServiceOp_Get_AssociationsPaging(t *testing.T) {
+	var requests []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			requests = append(requests, req.URL.Path+"?after="+req.URL.Query().Get("after"))
+			body := `{"id":"company001","properties":{"name":"Acme"},"associations":{"contacts":{"results":[{"id":"contact001","type":"company_to_contact"}],"paging":{"next":{"after":"1"}}},"deals":{"results":[{"id":"deal001","type":"company_to_deal"}]}}}`
+			switch req.URL.Path + "?" + req.URL.Query().Get("after") {
+			case "/crm/v3/objects/companies/company001/associations/contacts?":
+				body = `{"results":[{"id":"contact001","type":"company_to_contact"}],"paging":{"next":{"after":"1"}}}`
+			case "/crm/v3/objects/companies/company001/associations/contacts?1":
+				body = `{"results":[{"id":"contact002","type":"company_to_contact"}],"paging":{"next":{"after":"2"}}}`
+			case "/crm/v3/objects/companies/company001/associations/contacts?2":
+				body = `{"results":[{"id":"contact003","type":"company_to_contact"}]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	c := hubspot.NewMockClientWithHTTPClient(httpClient)
+
+	t.Run("Follow the inline associations", func(t *testing.T) {
+		requests = nil
+		got, err := c.CRM.Company.Get("company001", &hubspot.Company{}, &hubspot.RequestQueryOption{Associations: []string{"contacts", "deals"}})
+		if err != nil {
+			t.Fatalf("Get() unexpected error: %s", err)
+		}
+		want := hubspot.Associations{
+			"contacts": {Results: []hubspot.AssociationResult{
+				{ID: "contact001", Type: "company_to_contact"},
+				{ID: "contact002", Type: "company_to_contact"},
+				{ID: "contact003", Type: "company_to_contact"},
+			}},
+			"deals": {Results: []hubspot.AssociationResult{{ID: "deal001", Type: "company_to_deal"}}},
+		}
+		if diff := cmp.Diff(want, got.Associations); diff != "" {
+			t.Errorf("Get() associations mismatch (-want +got):%s", diff)
+		}
+		wantRequests := []string{
+			"/crm/v3/objects/companies/company001?after=",
+			"/crm/v3/objects/companies/company001/associations/contacts?after=1",
+			"/crm/v3/objects/companies/company001/associations/contacts?after=2",
+		}
+		if diff := cmp.Diff(wantRequests, requests); diff != "" {
+			t.Errorf("Get() requests mismatch (-want +got):%s", diff)
+		}
+	})
+
+	t.Run("Follow a single association type", func(t *testing.T) {
+		requests = nil
+		got, err := c.CRM.Company.Get("company001", &hubspot.Company{}, &hubspot.RequestQueryOption{Associations: []string{"contacts"}})
+		if err != nil {
+			t.Fatalf("Get() unexpected error: %s", err)
+		}
+		want := []hubspot.AssociationResult{
+			{ID: "contact001", Type: "company_to_contact"},
+			{ID: "contact002", Type: "company_to_contact"},
+			{ID: "contact003", Type: "company_to_contact"},
+		}
+		if diff := cmp.Diff(want, got.Associations["contacts"].Results); diff != "" {
+			t.Errorf("Get() associations mismatch (-want +got):%s", diff)
+		}
+		if diff := cmp.Diff(want, got.AssociationResults); diff != "" {
+			t.Errorf("Get() association results mismatch (-want +got):%s", diff)
+		}
+		if got.ID != "company001" || got.Properties.(*hubspot.Company).Name.String() != "Acme" {
+			t.Errorf("Get() company mismatch: got %+v", got)
+		}
+		wantRequests := []string{
+			"/crm/v3/objects/companies/company001?after=",
+			"/crm/v3/objects/companies/company001/associations/contacts?after=1",
+			"/crm/v3/objects/companies/company001/associations/contacts?after=2",
+		}
+		if diff := cmp.Diff(wantRequests, requests); diff != "" {
+			t.Errorf("Get() requests mismatch (-want +got):%s", diff)
+		}
+	})
+}
+
+func TestCompanyServiceOp_GetWithContacts(t *testing.T) {
+	var requests []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			reqBody, _ := ioutil.ReadAll(req.Body)
+			requests = append(requests, req.Method+" "+req.URL.Path+" "+req.URL.Query().Get("associations")+string(reqBody))
+			body := `{"id":"company001","properties":{"name":"Acme"},"associations":{"contacts":{"results":[{"id":"contact001","type":"company_to_contact"},{"id":"contact001","type":"company_to_contact_unlabeled"},{"id":"contact002","type":"company_to_contact"}]}}}`
+			if req.Method == http.MethodPost {
+				body = `{"status":"COMPLETE","results":[{"id":"contact001","properties":{"email":"jane@acme.com"}},{"id":"contact002","properties":{"email":"john@acme.com"}}]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	type contact struct {
+		Email *hubspot.HsStr `json:"email,omitempty"`
+	}
+	wantContacts := &hubspot.BatchResponse{
+		Results: []hubspot.ResponseResource{
+			{ID: "contact001", Properties: &contact{Email: hubspot.NewString("jane@acme.com")}},
+			{ID: "contact002", Properties: &contact{Email: hubspot.NewString("john@acme.com")}},
+		},
+	}
+	wantRequests := []string{
+		"GET /crm/v3/objects/companies/company001 contacts",
+		`POST /crm/v3/objects/contacts/batch/read {"properties":["email"],"inputs":[{"id":"contact001"},{"id":"contact002"}]}`,
+	}
+
+	contactOption := &hubspot.RequestQueryOption{Properties: []string{"email"}, NoDefaultProperties: true}
+	got, contacts, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.GetWithContacts("company001", &hubspot.Company{}, &contact{}, nil, contactOption)
+	if err != nil {
+		t.Fatalf("GetWithContacts() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(&hubspot.Company{Name: hubspot.NewString("Acme")}, got.Properties); diff != "" {
+		t.Errorf("GetWithContacts() company mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff(wantContacts, contacts); diff != "" {
+		t.Errorf("GetWithContacts() contacts mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("GetWithContacts() requests mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestCompanyServiceOp_GetWithContacts_Associations(t *testing.T) {
+	var queries []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body := `{"status":"COMPLETE","results":[{"id":"contact001"}]}`
+			if req.Method == http.MethodGet {
+				queries = append(queries, req.URL.Query().Get("associations"))
+				body = `{"id":"company001","associations":{"deals":{"results":[{"id":"deal001","type":"company_to_deal"}]},"contacts":{"results":[{"id":"contact001","type":"company_to_contact"}]}}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	c := hubspot.NewMockClientWithHTTPClient(httpClient)
+
+	option := &hubspot.RequestQueryOption{Associations: []string{"deals"}}
+	got, _, err := c.CRM.Company.GetWithContacts("company001", &hubspot.Company{}, &hubspot.Contact{}, option, nil)
+	if err != nil {
+		t.Fatalf("GetWithContacts() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"deal001"}, got.Associations.IDs("deals")); diff != "" {
+		t.Errorf("GetWithContacts() deals mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{"deals"}, option.Associations); diff != "" {
+		t.Errorf("GetWithContacts() option mismatch (-want +got):%s", diff)
+	}
+
+	// contacts is not requested twice when option already has it.
+	if _, _, err := c.CRM.Company.GetWithContacts("company001", &hubspot.Company{}, &hubspot.Contact{}, &hubspot.RequestQueryOption{Associations: []string{"contacts", "deals"}}, nil); err != nil {
+		t.Fatalf("GetWithContacts() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"deals,contacts", "contacts,deals"}, queries); diff != "" {
+		t.Errorf("GetWithContacts() associations mismatch (-want +got):%s", diff)
+	}
+}
@@ -0,0 +1,218 @@
+package hubspot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultConcurrency is the number of requests run in parallel when the concurrency is not specified.
+	// HubSpot allows about 10 requests per second for most apps, so this leaves room for other requests.
+	defaultConcurrency = 5
+
+	// maxRateLimitRetries is the number of times a call rejected with 429 Too Many Requests is retried.
+	maxRateLimitRetries = 3
+
+	// maxRetryAfter caps the wait requested by a Retry-After header, so that a far date does not stall the calls.
+	maxRetryAfter = time.Minute
+)
+
+// rateLimitBackoff is how long no new call is started after a 429 Too Many Requests.
+// HubSpot rate limits are applied per 10 seconds window.
+var rateLimitBackoff = 10 * time.Second
+
+// IndexedError is an error that occurred for the input at Index of a concurrent operation.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexedError) Error() string {
+	return fmt.Sprintf("index %d: %s", e.Index, e.Err)
+}
+
+func (e *IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError aggregates the errors of a concurrent operation, sorted by index.
+type MultiError []*IndexedError
+
+func (e MultiError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d error(s) occurred: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// RunConcurrent calls the functions with at most maxParallel of them running in parallel, and 5 if maxParallel is not positive.
+// Each function is expected to make HubSpot requests through the client.
+// Once a function fails with 429 Too Many Requests, no new function is started for the Retry-After of the response, or 10 seconds,
+// and the rate limited function is retried up to 3 times after that.
+// Once a function fails because the daily limit is exhausted, it is not retried and the functions not yet started
+// fail with the same error, matching ErrDailyLimitExceeded, instead of being started.
+// When the context is canceled, the functions not yet started are not started.
+// The errors are returned as MultiError whose indexes are those of fns, or nil if all functions succeeded.
+func (c *Client) RunConcurrent(ctx context.Context, fns []func() error, maxParallel int) error {
+	return c.runConcurrent(ctx, len(fns), maxParallel, func(ctx context.Context, i int) error {
+		return fns[i]()
+	})
+}
+
+// runConcurrent calls fn for each index in [0, n) with at most concurrency calls running in parallel.
+// When a call fails with 429 Too Many Requests, no new call is started until its Retry-After or rateLimitBackoff has passed,
+// then the call is retried up to maxRateLimitRetries times.
+// When a call fails with ErrDailyLimitExceeded, the remaining indexes are not started and fail with the same error.
+// When the context is canceled, the remaining indexes are not started and fail with the context error.
+// The errors are returned as MultiError, or nil if all calls succeeded.
+func (c *Client) runConcurrent(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	var (
+		mu          sync.Mutex
+		errs        MultiError
+		pausedUntil time.Time
+		exhausted   error
+		wg          sync.WaitGroup
+	)
+	addErr := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, &IndexedError{Index: i, Err: err})
+	}
+	pause := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		if until := time.Now().Add(d); until.After(pausedUntil) {
+			pausedUntil = until
+		}
+	}
+	// exhaust stops starting new calls, since they would fail in the same way until the daily limit is reset.
+	exhaust := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if exhausted == nil {
+			exhausted = err
+		}
+	}
+	wait := func() error {
+		mu.Lock()
+		d, err := time.Until(pausedUntil), exhausted
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+	call := func(i int) error {
+		for attempt := 0; ; attempt++ {
+			if err := wait(); err != nil {
+				return err
+			}
+			err := fn(ctx, i)
+			if errors.Is(err, ErrDailyLimitExceeded) {
+				exhaust(err)
+				return err
+			}
+			if !isRateLimited(err) || attempt == maxRateLimitRetries {
+				return err
+			}
+			pause(retryBackoff(err))
+			c.observeRetry(attempt + 1)
+		}
+	}
+
+	indexes := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					addErr(i, err)
+					continue
+				}
+				if err := call(i); err != nil {
+					addErr(i, err)
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+	return errs
+}
+
+// retryBackoff returns how long to wait before retrying the rate limited call.
+// The Retry-After of the response is used if HubSpot sent a valid one, up to maxRetryAfter, and rateLimitBackoff otherwise.
+// A Retry-After of 0 or a past date is honored, retrying right away.
+func retryBackoff(err error) time.Duration {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.HasRetryAfter {
+		return rateLimitBackoff
+	}
+	if apiErr.RetryAfter > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return apiErr.RetryAfter
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either delay seconds, e.g. "10",
+// or an HTTP-date, e.g. "Wed, 21 Oct 2015 07:28:00 GMT", relative to now.
+// It reports false if the value is absent or unparseable. A date in the past is a delay of 0.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if d := date.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// isRateLimited reports whether the error is a 429 Too Many Requests response from HubSpot.
+func isRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
@@ -0,0 +1,240 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bendingspoons.com/hubspot"
+)
+
+func TestClient_RunConcurrent(t *testing.T) {
+	backoff := *hubspot.ExportRateLimitBackoff
+	*hubspot.ExportRateLimitBackoff = 10 * time.Millisecond
+	defer func() { *hubspot.ExportRateLimitBackoff = backoff }()
+
+	c := hubspot.NewMockClient(&hubspot.MockConfig{})
+	errBoom := errors.New("boom")
+
+	t.Run("Collect errors by index", func(t *testing.T) {
+		var calls int32
+		fns := make([]func() error, 4)
+		for i := range fns {
+			i := i
+			fns[i] = func() error {
+				atomic.AddInt32(&calls, 1)
+				if i%2 == 1 {
+					return errBoom
+				}
+				return nil
+			}
+		}
+
+		err := c.RunConcurrent(context.Background(), fns, 2)
+		var multiErr hubspot.MultiError
+		if !errors.As(err, &multiErr) {
+			t.Fatalf("RunConcurrent() error mismatch: want MultiError got %v", err)
+		}
+		if len(multiErr) != 2 || multiErr[0].Index != 1 || multiErr[1].Index != 3 || !errors.Is(multiErr[0], errBoom) {
+			t.Errorf("RunConcurrent() error mismatch: want errors at index 1 and 3 got %v", multiErr)
+		}
+		if calls != 4 {
+			t.Errorf("RunConcurrent() calls mismatch: want 4 got %d", calls)
+		}
+	})
+
+	t.Run("Retry rate limited calls after backoff", func(t *testing.T) {
+		var calls int32
+		fns := []func() error{
+			func() error {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					return &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests}
+				}
+				return nil
+			},
+		}
+
+		start := time.Now()
+		if err := c.RunConcurrent(context.Background(), fns, 1); err != nil {
+			t.Fatalf("RunConcurrent() unexpected error: %s", err)
+		}
+		if calls != 2 {
+			t.Errorf("RunConcurrent() calls mismatch: want 2 got %d", calls)
+		}
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Errorf("RunConcurrent() did not back off: elapsed %s", elapsed)
+		}
+	})
+
+	t.Run("Back off for the Retry-After of the response", func(t *testing.T) {
+		var calls int32
+		fns := []func() error{
+			func() error {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					return &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, RetryAfter: 50 * time.Millisecond, HasRetryAfter: true}
+				}
+				return nil
+			},
+		}
+
+		start := time.Now()
+		if err := c.RunConcurrent(context.Background(), fns, 1); err != nil {
+			t.Fatalf("RunConcurrent() unexpected error: %s", err)
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("RunConcurrent() did not back off for Retry-After: elapsed %s", elapsed)
+		}
+	})
+
+	t.Run("Fail fast when the daily limit is exhausted", func(t *testing.T) {
+		var calls int32
+		fns := make([]func() error, 3)
+		for i := range fns {
+			fns[i] = func() error {
+				atomic.AddInt32(&calls, 1)
+				return &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, PolicyName: hubspot.RateLimitPolicyDaily}
+			}
+		}
+
+		err := c.RunConcurrent(context.Background(), fns, 1)
+		var multiErr hubspot.MultiError
+		if !errors.As(err, &multiErr) || len(multiErr) != 3 {
+			t.Fatalf("RunConcurrent() error mismatch: want MultiError of 3 errors got %v", err)
+		}
+		for _, err := range multiErr {
+			if !errors.Is(err, hubspot.ErrDailyLimitExceeded) {
+				t.Errorf("RunConcurrent() error mismatch: want ErrDailyLimitExceeded got %v", err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("RunConcurrent() calls mismatch: want 1 got %d", calls)
+		}
+	})
+
+	t.Run("Give up after retries", func(t *testing.T) {
+		var calls int32
+		fns := []func() error{
+			func() error {
+				atomic.AddInt32(&calls, 1)
+				return &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests}
+			},
+		}
+
+		if err := c.RunConcurrent(context.Background(), fns, 1); err == nil {
+			t.Fatal("RunConcurrent() error mismatch: want error got nil")
+		}
+		if calls != 4 {
+			t.Errorf("RunConcurrent() calls mismatch: want 4 got %d", calls)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "Delay seconds", value: "10", want: 10 * time.Second, wantOK: true},
+		{name: "HTTP-date", value: "Wed, 21 Oct 2015 07:28:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "HTTP-date in the past", value: "Wed, 21 Oct 2015 07:27:00 GMT", want: 0, wantOK: true},
+		{name: "Absent", value: "", wantOK: false},
+		{name: "Negative seconds", value: "-1", wantOK: false},
+		{name: "Unparseable", value: "soon", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := hubspot.ExportParseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter() mismatch: want %s, %t got %s, %t", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestCheckResponseError_PolicyName(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    http.Header
+		body      string
+		want      string
+		wantDaily bool
+	}{
+		{
+			name:      "Daily policy in the body",
+			header:    http.Header{},
+			body:      `{"status":"error","message":"You have reached your daily limit.","errorType":"RATE_LIMIT","policyName":"DAILY"}`,
+			want:      hubspot.RateLimitPolicyDaily,
+			wantDaily: true,
+		},
+		{
+			name:   "Secondly policy in the body",
+			header: http.Header{"X-Hubspot-Ratelimit-Daily-Remaining": []string{"1000"}},
+			body:   `{"status":"error","message":"You have reached your secondly limit.","errorType":"RATE_LIMIT","policyName":"SECONDLY"}`,
+			want:   hubspot.RateLimitPolicySecondly,
+		},
+		{
+			name:      "No daily requests remaining",
+			header:    http.Header{"X-Hubspot-Ratelimit-Daily-Remaining": []string{"0"}},
+			body:      `{"status":"error","message":"You have reached your daily limit."}`,
+			want:      hubspot.RateLimitPolicyDaily,
+			wantDaily: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     tt.header,
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+			}
+			err := hubspot.CheckResponseError(resp)
+			var apiErr *hubspot.APIError
+			if !errors.As(err, &apiErr) || apiErr.PolicyName != tt.want {
+				t.Errorf("CheckResponseError() mismatch: want PolicyName %s got %v", tt.want, err)
+			}
+			if got := errors.Is(err, hubspot.ErrDailyLimitExceeded); got != tt.wantDaily {
+				t.Errorf("errors.Is(ErrDailyLimitExceeded) mismatch: want %t got %t", tt.wantDaily, got)
+			}
+		})
+	}
+}
+
+func TestCheckResponseError_RetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"2"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"status":"error","message":"You have reached your secondly limit.","category":"RATE_LIMITS"}`)),
+	}
+	var apiErr *hubspot.APIError
+	if err := hubspot.CheckResponseError(resp); !errors.As(err, &apiErr) || apiErr.RetryAfter != 2*time.Second || !apiErr.HasRetryAfter {
+		t.Errorf("CheckResponseError() mismatch: want RetryAfter 2s got %v", err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want time.Duration
+	}{
+		{name: "Retry-After", err: &hubspot.APIError{RetryAfter: 2 * time.Second, HasRetryAfter: true}, want: 2 * time.Second},
+		{name: "Zero Retry-After", err: &hubspot.APIError{HasRetryAfter: true}, want: 0},
+		{name: "Retry-After above the cap", err: &hubspot.APIError{RetryAfter: time.Hour, HasRetryAfter: true}, want: hubspot.ExportMaxRetryAfter},
+		{name: "No Retry-After", err: &hubspot.APIError{}, want: *hubspot.ExportRateLimitBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hubspot.ExportRetryBackoff(tt.err); got != tt.want {
+				t.Errorf("retryBackoff() mismatch: want %s got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package hubspot
 
+import (
+	"context"
+	"strings"
+)
+
 const (
 	contactBasePath = "contacts"
 )
@@ -11,6 +16,7 @@ const (
 type ContactService interface {
 	Get(contactID string, contact interface{}, option *RequestQueryOption) (*ResponseResource, error)
 	Create(contact interface{}) (*ResponseResource, error)
+	CreateWithAssociations(contact interface{}, associations []CreateAssociation) (*ResponseResource, error)
 	Update(contactID string, contact interface{}) (*ResponseResource, error)
 	Delete(contactID string) error
 	Search(contact interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error)
@@ -51,6 +57,7 @@ type Contact struct {
 	FirstName                                   *HsStr  `json:"firstname,omitempty"`
 	Gender                                      *HsStr  `json:"gender,omitempty"`
 	GraduationDate                              *HsStr  `json:"graduation_date,omitempty"`
+	HsAdditionalEmails                          *HsStr  `json:"hs_additional_emails,omitempty"`
 	HsAnalyticsAveragePageViews                 *HsStr  `json:"hs_analytics_average_page_views,omitempty"`
 	HsAnalyticsFirstReferrer                    *HsStr  `json:"hs_analytics_first_referrer,omitempty"`
 	HsAnalyticsFirstTimestamp                   *HsTime `json:"hs_analytics_first_timestamp,omitempty"`
@@ -211,6 +218,7 @@ var defaultContactFields = []string{
 	"firstname",
 	"gender",
 	"graduation_date",
+	"hs_additional_emails",
 	"hs_analytics_average_page_views",
 	"hs_analytics_first_referrer",
 	"hs_analytics_first_timestamp",
@@ -345,21 +353,75 @@ var defaultContactFields = []string{
 	"user_type",
 }
 
+// AdditionalEmails returns the semicolon-separated additional emails of the contact.
+// nil is returned if there is no additional email.
+func (c *Contact) AdditionalEmails() []string {
+	return c.HsAdditionalEmails.Values()
+}
+
+// AddAdditionalEmail adds the email to the semicolon-separated additional emails.
+// Emails are compared case-insensitively, and the email is not added if it is the primary email or already exists.
+func (c *Contact) AddAdditionalEmail(email string) {
+	email = strings.TrimSpace(email)
+	if email == "" || sameEmail(c.Email.String(), email) {
+		return
+	}
+	for _, v := range c.HsAdditionalEmails.Values() {
+		if sameEmail(v, email) {
+			return
+		}
+	}
+	c.HsAdditionalEmails = c.HsAdditionalEmails.AddToSet(email)
+}
+
+// RemoveAdditionalEmail removes all occurrences of the email from the semicolon-separated additional emails.
+// Emails are compared case-insensitively. If no additional emails remain, HsAdditionalEmails is set to ClearString(),
+// so that an update of the contact clears them in HubSpot. It is left nil if it was not set.
+func (c *Contact) RemoveAdditionalEmail(email string) {
+	if c.HsAdditionalEmails == nil {
+		return
+	}
+	values := []string{}
+	for _, v := range c.HsAdditionalEmails.Values() {
+		if !sameEmail(v, email) {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		c.HsAdditionalEmails = ClearString()
+		return
+	}
+	c.HsAdditionalEmails = NewString(strings.Join(values, multiValueSeparator))
+}
+
+// sameEmail reports whether the emails are the same, ignoring case and surrounding spaces.
+func sameEmail(a, b string) bool {
+	return strings.ToLower(strings.TrimSpace(a)) == strings.ToLower(strings.TrimSpace(b))
+}
+
 // Get gets a contact.
 // In order to bind the get content, a structure must be specified as an argument.
 // Also, if you want to gets a custom field, you need to specify the field name.
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+// The association types, e.g. &hubspot.RequestQueryOption{Associations: []string{"deals"}}, are returned with the contact
+// keyed by type, e.g. resource.Associations["deals"], however many types are given.
+// When a single association type is given, its associations are also set in ResponseResource.AssociationResults.
+// The associations are paginated by HubSpot, and the pages are followed so that all associated objects are returned.
 func (s *ContactServiceOp) Get(contactID string, contact interface{}, option *RequestQueryOption) (*ResponseResource, error) {
-	resource := &ResponseResource{Properties: contact}
+	if option == nil {
+		option = &RequestQueryOption{}
+	}
+	ctx := context.Background()
 	path := s.contactPath + "/" + contactID
-	if len(option.Associations) != 0 {
-		path += "/associations/" + option.Associations[0]
-		resource = &ResponseResource{}
+	resource := &ResponseResource{Properties: contact}
+	if err := getWithHistory(ctx, s.client, path, resource, option.setupProperties(defaultContactFields)); err != nil {
+		return nil, err
 	}
-	if err := s.client.Get(path, resource, option.setupProperties(defaultContactFields)); err != nil {
+	if err := s.client.followAssociations(ctx, path, resource.Associations); err != nil {
 		return nil, err
 	}
+	resource.setSingleAssociationResults(option.Associations)
 	return resource, nil
 }
 
@@ -367,7 +429,20 @@ func (s *ContactServiceOp) Get(contactID string, contact interface{}, option *Re
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Contact in your own structure.
 func (s *ContactServiceOp) Create(contact interface{}) (*ResponseResource, error) {
-	req := &RequestPayload{Properties: contact}
+	return s.CreateWithAssociations(contact, nil)
+}
+
+// CreateWithAssociations creates a new contact and associates it with other objects in the same request.
+// Unlike associating after Create, no contact is left without its associations if the association fails.
+// In order to bind the created content, a structure must be specified as an argument.
+// e.g. associate with a company
+//
+//	[]hubspot.CreateAssociation{{
+//		To:    hubspot.AssociationTo{ID: "companyID"},
+//		Types: []hubspot.AssociationSpec{{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDContactToCompany}},
+//	}}
+func (s *ContactServiceOp) CreateWithAssociations(contact interface{}, associations []CreateAssociation) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: contact, Associations: associations}
 	resource := &ResponseResource{Properties: contact}
 	if err := s.client.Post(s.contactPath, req, resource); err != nil {
 		return nil, err
@@ -409,12 +484,12 @@ func (s *ContactServiceOp) Delete(contactID string) error {
 // In order to bind the get content, a structure must be specified as an argument.
 // Also, if you want to gets a custom field, you need to specify the field name.
 // If you specify a non-existent field, it will be ignored.
-// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+// The default fields are requested in the same way as Get.
+// e.g. &hubspot.RequestSearchOption{ CustomProperties: []string{"custom_a", "custom_b"}}
+// An error is returned without making the request if the filter groups exceed the limits of HubSpot.
 func (s *ContactServiceOp) Search(contact interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error) {
-	resources := []ResponseResource{}
-	resources = append(resources, ResponseResource{Properties: contact})
-	resource := &ResponseResourceMulti{Results: resources}
-	if err := s.client.Post(s.contactPath+"/search", option, resource); err != nil {
+	resource := &ResponseResourceMulti{}
+	if err := s.client.search(s.contactPath, option.setupProperties(defaultContactFields), contact, resource); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -3,6 +3,7 @@ package hubspot_test
 import (
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -641,10 +642,8 @@ func TestContactServiceOp_AssociateAnotherObj(t *testing.T) {
 			want: &hubspot.ResponseResource{
 				ID:       "contact001",
 				Archived: false,
-				Associations: &hubspot.Associations{
-					Deals: struct {
-						Results []hubspot.AssociationResult `json:"results"`
-					}{
+				Associations: hubspot.Associations{
+					"deals": {
 						Results: []hubspot.AssociationResult{
 							{ID: "deal001", Type: string(hubspot.AssociationTypeContactToDeal)},
 						},
@@ -675,3 +674,163 @@ func TestContactServiceOp_AssociateAnotherObj(t *testing.T) {
 		})
 	}
 }
+
+func TestContact_AddAdditionalEmail(t *testing.T) {
+	tests := []struct {
+		name             string
+		additionalEmails *hubspot.HsStr
+		add              string
+		want             *hubspot.HsStr
+	}{
+		{
+			name:             "Success with nil additional emails",
+			additionalEmails: nil,
+			add:              "b@example.com",
+			want:             hubspot.NewString("b@example.com"),
+		},
+		{
+			name:             "Success with existing additional emails",
+			additionalEmails: hubspot.NewString("b@example.com"),
+			add:              "c@example.com",
+			want:             hubspot.NewString("b@example.com;c@example.com"),
+		},
+		{
+			name:             "Success with already added email in another case",
+			additionalEmails: hubspot.NewString("b@example.com"),
+			add:              "B@Example.com",
+			want:             hubspot.NewString("b@example.com"),
+		},
+		{
+			name:             "Success with primary email",
+			additionalEmails: nil,
+			add:              "A@example.com",
+			want:             nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &hubspot.Contact{Email: hubspot.NewString("a@example.com"), HsAdditionalEmails: tt.additionalEmails}
+			c.AddAdditionalEmail(tt.add)
+			if diff := cmp.Diff(tt.want, c.HsAdditionalEmails); diff != "" {
+				t.Errorf("AddAdditionalEmail() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestContact_RemoveAdditionalEmail(t *testing.T) {
+	tests := []struct {
+		name             string
+		additionalEmails *hubspot.HsStr
+		remove           string
+		want             *hubspot.HsStr
+	}{
+		{
+			name:             "Success with nil additional emails",
+			additionalEmails: nil,
+			remove:           "b@example.com",
+			want:             nil,
+		},
+		{
+			name:             "Success with email in another case",
+			additionalEmails: hubspot.NewString("b@example.com;C@example.com"),
+			remove:           "c@EXAMPLE.com",
+			want:             hubspot.NewString("b@example.com"),
+		},
+		{
+			name:             "Success with last email",
+			additionalEmails: hubspot.NewString("b@example.com;B@example.com"),
+			remove:           "b@example.com",
+			want:             hubspot.ClearString(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &hubspot.Contact{HsAdditionalEmails: tt.additionalEmails}
+			c.RemoveAdditionalEmail(tt.remove)
+			if diff := cmp.Diff(tt.want, c.HsAdditionalEmails); diff != "" {
+				t.Errorf("RemoveAdditionalEmail() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestContact_RemoveAdditionalEmail_Update(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"contact001","properties":{}}`),
+	}
+	c := &hubspot.Contact{HsAdditionalEmails: hubspot.NewString("b@example.com")}
+	c.RemoveAdditionalEmail("b@example.com")
+
+	if _, err := hubspot.NewMockClient(conf).CRM.Contact.Update("contact001", c); err != nil {
+		t.Fatalf("Update() unexpected error: %s", err)
+	}
+	if want := `"hs_additional_emails":""`; !strings.Contains(string(conf.Requests[0].Body), want) {
+		t.Errorf("Update() body mismatch: want %s in %s", want, string(conf.Requests[0].Body))
+	}
+}
+
+func TestContactServiceOp_Get_SingleAssociationType(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"contact001","properties":{"email":"hubspot@example.com"},"associations":{"deals":{"results":[{"id":"deal001","type":"contact_to_deal"}]}}}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID: "contact001",
+		Associations: hubspot.Associations{
+			"deals": {Results: []hubspot.AssociationResult{{ID: "deal001", Type: string(hubspot.AssociationTypeContactToDeal)}}},
+		},
+		AssociationResults: []hubspot.AssociationResult{{ID: "deal001", Type: string(hubspot.AssociationTypeContactToDeal)}},
+		Properties:         &hubspot.Contact{Email: hubspot.NewString("hubspot@example.com")},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Contact.Get("contact001", &hubspot.Contact{}, &hubspot.RequestQueryOption{Associations: []string{"deals"}})
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/contacts/contact001"; req.URL.Path != want {
+		t.Errorf("Get() request path mismatch: want %s got %s", want, req.URL.Path)
+	}
+	if want := "deals"; req.URL.Query().Get("associations") != want {
+		t.Errorf("Get() request associations mismatch: want %s got %s", want, req.URL.Query().Get("associations"))
+	}
+}
+
+func TestContactServiceOp_CreateWithAssociations(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"contact001","properties":{"email":"hubspot@example.com"},"archived":false}`),
+	}
+	associations := []hubspot.CreateAssociation{
+		{
+			To: hubspot.AssociationTo{ID: "company001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDContactToCompany},
+			},
+		},
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "contact001",
+		Properties: &hubspot.Contact{Email: hubspot.NewString("hubspot@example.com")},
+	}
+	wantBody := `{"properties":{"email":"hubspot@example.com"},"associations":[{"to":{"id":"company001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":279}]}]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Contact.CreateWithAssociations(&hubspot.Contact{Email: hubspot.NewString("hubspot@example.com")}, associations)
+	if err != nil {
+		t.Fatalf("CreateWithAssociations() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreateWithAssociations() response mismatch (-want +got):%s", diff)
+	}
+	if got := string(conf.Requests[0].Body); got != wantBody {
+		t.Errorf("CreateWithAssociations() request body mismatch: want %s got %s", wantBody, got)
+	}
+}
@@ -9,16 +9,36 @@ const (
 )
 
 type CRM struct {
-	Company  CompanyService
-	Contact  ContactService
-	Deal     DealService
-	Owner    OwnerService
-	Pipeline PipelineService
+	Association        AssociationService
+	Call               CallService
+	Company            CompanyService
+	Contact            ContactService
+	Deal               DealService
+	Email              EmailService
+	FeedbackSubmission FeedbackSubmissionService
+	List               ListService
+	Meeting            MeetingService
+	Object             ObjectService
+	Owner              OwnerService
+	Pipeline           PipelineService
+	Property           PropertyService
+	Quote              QuoteService
+	Schema             SchemaService
+	Timeline           TimelineService
 }
 
 func newCRM(c *Client) *CRM {
 	crmPath := fmt.Sprintf("%s/%s", crmBasePath, c.apiVersion)
 	return &CRM{
+		Association: &AssociationServiceOp{
+			associationPath: fmt.Sprintf("%s/%s/%s", crmBasePath, associationAPIVersion, associationBasePath),
+			objectPath:      fmt.Sprintf("%s/%s/%s", crmBasePath, associationAPIVersion, objectsBasePath),
+			client:          c,
+		},
+		Call: &CallServiceOp{
+			callPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, callBasePath),
+			client:   c,
+		},
 		Company: &CompanyServiceOp{
 			companyPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, companyBasePath),
 			client:      c,
@@ -31,6 +51,26 @@ func newCRM(c *Client) *CRM {
 			dealPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, dealBasePath),
 			client:   c,
 		},
+		Email: &EmailServiceOp{
+			emailPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, emailBasePath),
+			client:    c,
+		},
+		FeedbackSubmission: &FeedbackSubmissionServiceOp{
+			feedbackSubmissionPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, feedbackSubmissionBasePath),
+			client:                 c,
+		},
+		List: &ListServiceOp{
+			listPath: fmt.Sprintf("%s/%s", crmPath, listBasePath),
+			client:   c,
+		},
+		Meeting: &MeetingServiceOp{
+			meetingPath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, meetingBasePath),
+			client:      c,
+		},
+		Object: &ObjectServiceOp{
+			objectPath: fmt.Sprintf("%s/%s", crmPath, objectsBasePath),
+			client:     c,
+		},
 		Owner: &OwnerServiceOp{
 			ownerPath: fmt.Sprintf("%s/%s", crmPath, ownerBasePath),
 			client:    c,
@@ -39,5 +79,21 @@ func newCRM(c *Client) *CRM {
 			pipelinePath: fmt.Sprintf("%s/%s", crmPath, pipelineBasePath),
 			client:       c,
 		},
+		Property: &PropertyServiceOp{
+			propertyPath: fmt.Sprintf("%s/%s", crmPath, propertyBasePath),
+			client:       c,
+		},
+		Quote: &QuoteServiceOp{
+			quotePath: fmt.Sprintf("%s/%s/%s", crmPath, objectsBasePath, quoteBasePath),
+			client:    c,
+		},
+		Schema: &SchemaServiceOp{
+			schemaPath: fmt.Sprintf("%s/%s", crmPath, schemaBasePath),
+			client:     c,
+		},
+		Timeline: &TimelineServiceOp{
+			timelinePath: fmt.Sprintf("%s/%s", crmPath, timelineBasePath),
+			client:       c,
+		},
 	}
 }
@@ -1,5 +1,12 @@
 package hubspot
 
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 const (
 	dealBasePath = "deals"
 )
@@ -11,9 +18,11 @@ const (
 type DealService interface {
 	Get(dealID string, deal interface{}, option *RequestQueryOption) (*ResponseResource, error)
 	Create(deal interface{}) (*ResponseResource, error)
+	CreateWithAssociations(deal interface{}, associations []CreateAssociation) (*ResponseResource, error)
 	Update(dealID string, deal interface{}) (*ResponseResource, error)
 	Delete(dealID string) error
 	AssociateAnotherObj(dealID string, conf *AssociationConfig) (*ResponseResource, error)
+	SumProperty(option *RequestSearchOption, property string) (float64, error)
 }
 
 // DealServiceOp handles communication with the product related methods of the HubSpot API.
@@ -26,29 +35,29 @@ var _ DealService = (*DealServiceOp)(nil)
 
 // Deal represents a HubSpot deal.
 type Deal struct {
-	Amount                  *HsStr `json:"amount,omitempty"`
-	AmountInCompanyCurrency *HsStr `json:"amount_in_home_currency,omitempty"`
-	AnnualContractValue     *HsStr `json:"hs_acv,omitempty"`
-	AnnualRecurringRevenue  *HsStr `json:"hs_arr,omitempty"`
-	ClosedLostReason        *HsStr `json:"closed_lost_reason,omitempty"`
-	ClosedWonReason         *HsStr `json:"closed_won_reason,omitempty"`
-	DealDescription         *HsStr `json:"description,omitempty"`
-	DealName                *HsStr `json:"dealname,omitempty"`
-	DealOwnerID             *HsStr `json:"hubspot_owner_id,omitempty"`
-	DealStage               *HsStr `json:"dealstage,omitempty"`
-	DealType                *HsStr `json:"dealtype,omitempty"`
-	ForecastAmount          *HsStr `json:"hs_forecast_amount,omitempty"`
-	ForecastCategory        *HsStr `json:"hs_forecast_category,omitempty"`
-	ForecastProbability     *HsStr `json:"hs_forecast_probability,omitempty"`
-	MonthlyRecurringRevenue *HsStr `json:"hs_mrr,omitempty"`
-	NextStep                *HsStr `json:"hs_next_step,omitempty"`
-	NumberOfContacts        *HsStr `json:"num_associated_contacts,omitempty"`
-	NumberOfSalesActivities *HsStr `json:"num_notes,omitempty"`
-	NumberOfTimesContacted  *HsStr `json:"num_contacted_notes,omitempty"`
-	ObjectID                *HsStr `json:"hs_object_id,omitempty"`
-	PipeLine                *HsStr `json:"pipeline,omitempty"`
-	TeamID                  *HsStr `json:"hubspot_team_id,omitempty"`
-	TotalContractValue      *HsStr `json:"hs_tcv,omitempty"`
+	Amount                  *HsFloat `json:"amount,omitempty"`
+	AmountInCompanyCurrency *HsStr   `json:"amount_in_home_currency,omitempty"`
+	AnnualContractValue     *HsStr   `json:"hs_acv,omitempty"`
+	AnnualRecurringRevenue  *HsStr   `json:"hs_arr,omitempty"`
+	ClosedLostReason        *HsStr   `json:"closed_lost_reason,omitempty"`
+	ClosedWonReason         *HsStr   `json:"closed_won_reason,omitempty"`
+	DealDescription         *HsStr   `json:"description,omitempty"`
+	DealName                *HsStr   `json:"dealname,omitempty"`
+	DealOwnerID             *HsStr   `json:"hubspot_owner_id,omitempty"`
+	DealStage               *HsStr   `json:"dealstage,omitempty"`
+	DealType                *HsStr   `json:"dealtype,omitempty"`
+	ForecastAmount          *HsStr   `json:"hs_forecast_amount,omitempty"`
+	ForecastCategory        *HsStr   `json:"hs_forecast_category,omitempty"`
+	ForecastProbability     *HsStr   `json:"hs_forecast_probability,omitempty"`
+	MonthlyRecurringRevenue *HsStr   `json:"hs_mrr,omitempty"`
+	NextStep                *HsStr   `json:"hs_next_step,omitempty"`
+	NumberOfContacts        *HsStr   `json:"num_associated_contacts,omitempty"`
+	NumberOfSalesActivities *HsStr   `json:"num_notes,omitempty"`
+	NumberOfTimesContacted  *HsStr   `json:"num_contacted_notes,omitempty"`
+	ObjectID                *HsStr   `json:"hs_object_id,omitempty"`
+	PipeLine                *HsStr   `json:"pipeline,omitempty"`
+	TeamID                  *HsStr   `json:"hubspot_team_id,omitempty"`
+	TotalContractValue      *HsStr   `json:"hs_tcv,omitempty"`
 
 	CreateDate        *HsTime `json:"createdate,omitempty"`
 	CloseDate         *HsTime `json:"closedate,omitempty"`
@@ -111,9 +120,14 @@ var defaultDealFields = []string{
 // Also, if you want to gets a custom field, you need to specify the field name.
 // If you specify a non-existent field, it will be ignored.
 // e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+// The associations are paginated by HubSpot, and the pages are followed so that all associated objects are returned.
 func (s *DealServiceOp) Get(dealID string, deal interface{}, option *RequestQueryOption) (*ResponseResource, error) {
 	resource := &ResponseResource{Properties: deal}
-	if err := s.client.Get(s.dealPath+"/"+dealID, resource, option.setupProperties(defaultDealFields)); err != nil {
+	path := s.dealPath + "/" + dealID
+	if err := s.client.Get(path, resource, option.setupProperties(defaultDealFields)); err != nil {
+		return nil, err
+	}
+	if err := s.client.followAssociations(context.Background(), path, resource.Associations); err != nil {
 		return nil, err
 	}
 	return resource, nil
@@ -123,7 +137,20 @@ func (s *DealServiceOp) Get(dealID string, deal interface{}, option *RequestQuer
 // In order to bind the created content, a structure must be specified as an argument.
 // When using custom fields, please embed hubspot.Deal in your own structure.
 func (s *DealServiceOp) Create(deal interface{}) (*ResponseResource, error) {
-	req := &RequestPayload{Properties: deal}
+	return s.CreateWithAssociations(deal, nil)
+}
+
+// CreateWithAssociations creates a new deal and associates it with other objects in the same request.
+// Unlike associating after Create, no deal is left without its associations if the association fails.
+// In order to bind the created content, a structure must be specified as an argument.
+// e.g. associate with a company
+//
+//	[]hubspot.CreateAssociation{{
+//		To:    hubspot.AssociationTo{ID: "companyID"},
+//		Types: []hubspot.AssociationSpec{{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDDealToCompany}},
+//	}}
+func (s *DealServiceOp) CreateWithAssociations(deal interface{}, associations []CreateAssociation) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: deal, Associations: associations}
 	resource := &ResponseResource{Properties: deal}
 	if err := s.client.Post(s.dealPath, req, resource); err != nil {
 		return nil, err
@@ -160,3 +187,51 @@ func (s *DealServiceOp) Delete(dealID string) error {
 	}
 	return nil
 }
+
+// SumProperty sums the numeric property of all deals matching the search, e.g. "amount" for the value of the open pipeline.
+// HubSpot search cannot aggregate, so the deals are searched page by page and summed by the client,
+// which takes one request per 200 matching deals. Only the property is requested, whatever the properties of the option.
+// The deals without a value are skipped, and an error is returned if a value is not a number.
+// HubSpot search returns up to 10,000 results per query, so an error is returned if more deals match instead of a partial sum.
+func (s *DealServiceOp) SumProperty(option *RequestSearchOption, property string) (float64, error) {
+	opts := &RequestSearchOption{}
+	if option != nil {
+		opts = option.clone()
+	}
+	opts.Properties = []string{property}
+	opts.Limit = maxSearchLimit
+	opts.After = ""
+
+	var sum float64
+	for {
+		page := &ResponseResourceMulti{}
+		if err := s.client.search(s.dealPath, opts, nil, page); err != nil {
+			return 0, err
+		}
+		if page.Total > searchResultLimit {
+			return 0, fmt.Errorf("unable to sum %s: %d deals match the search, more than the %d results HubSpot search returns", property, page.Total, searchResultLimit)
+		}
+		for _, deal := range page.Results {
+			properties, _ := deal.Properties.(map[string]interface{})
+			// HubSpot returns the values of number properties as strings.
+			switch value := properties[property].(type) {
+			case float64:
+				sum += value
+			case string:
+				if strings.TrimSpace(value) == "" {
+					continue
+				}
+				f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					return 0, fmt.Errorf("unable to sum %s: the value of deal %s is not a number: %w", property, deal.ID, err)
+				}
+				sum += f
+			}
+		}
+		after, ok, err := s.client.nextCursor(page.Paging)
+		if err != nil || !ok {
+			return sum, err
+		}
+		opts.After = after
+	}
+}
@@ -1,6 +1,9 @@
 package hubspot_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -11,7 +14,7 @@ import (
 
 func TestDealServiceOp_Create(t *testing.T) {
 	deal := &hubspot.Deal{
-		Amount:      hubspot.NewString("1500.00"),
+		Amount:      hubspot.NewFloat(1500),
 		DealName:    hubspot.NewString("Custom data integrations"),
 		DealStage:   hubspot.NewString("presentation scheduled"),
 		DealOwnerID: hubspot.NewString("910901"),
@@ -50,7 +53,7 @@ func TestDealServiceOp_Create(t *testing.T) {
 				ID:       "512",
 				Archived: false,
 				Properties: &hubspot.Deal{
-					Amount:           hubspot.NewString("1500.00"),
+					Amount:           hubspot.NewFloat(1500),
 					DealName:         hubspot.NewString("Custom data integrations"),
 					DealStage:        hubspot.NewString("presentation scheduled"),
 					DealOwnerID:      hubspot.NewString("910901"),
@@ -105,7 +108,7 @@ func TestDealServiceOp_Create(t *testing.T) {
 
 func TestDealServiceOp_Update(t *testing.T) {
 	deal := &hubspot.Deal{
-		Amount:      hubspot.NewString("1500.00"),
+		Amount:      hubspot.NewFloat(1500),
 		DealName:    hubspot.NewString("Custom data integrations"),
 		DealStage:   hubspot.NewString("presentation scheduled"),
 		DealOwnerID: hubspot.NewString("910901"),
@@ -146,7 +149,7 @@ func TestDealServiceOp_Update(t *testing.T) {
 				ID:       "512",
 				Archived: false,
 				Properties: &hubspot.Deal{
-					Amount:           hubspot.NewString("1500.00"),
+					Amount:           hubspot.NewFloat(1500),
 					DealName:         hubspot.NewString("Custom data integrations"),
 					DealStage:        hubspot.NewString("presentation scheduled"),
 					DealOwnerID:      hubspot.NewString("910901"),
@@ -241,7 +244,7 @@ func TestDealServiceOp_Get(t *testing.T) {
 				ID:       "512",
 				Archived: false,
 				Properties: &hubspot.Deal{
-					Amount:                  hubspot.NewString("1500.00"),
+					Amount:                  hubspot.NewFloat(1500),
 					AmountInCompanyCurrency: hubspot.NewString("1500.00"),
 					AnnualContractValue:     hubspot.NewString("1000.00"),
 					AnnualRecurringRevenue:  nil,
@@ -302,7 +305,7 @@ func TestDealServiceOp_Get(t *testing.T) {
 				Archived: false,
 				Properties: &CustomFields{
 					Deal: hubspot.Deal{
-						Amount:                  hubspot.NewString("1500.00"),
+						Amount:                  hubspot.NewFloat(1500),
 						AmountInCompanyCurrency: hubspot.NewString("1500.00"),
 						AnnualContractValue:     hubspot.NewString("1000.00"),
 						AnnualRecurringRevenue:  nil,
@@ -418,10 +421,8 @@ func TestDealServiceOp_AssociateAnotherObj(t *testing.T) {
 			want: &hubspot.ResponseResource{
 				ID:       "512",
 				Archived: false,
-				Associations: &hubspot.Associations{
-					Contacts: struct {
-						Results []hubspot.AssociationResult `json:"results"`
-					}{
+				Associations: hubspot.Associations{
+					"contacts": {
 						Results: []hubspot.AssociationResult{
 							{ID: "20074", Type: "deal_to_contact"},
 						},
@@ -485,3 +486,101 @@ func TestDealServiceOp_AssociateAnotherObj(t *testing.T) {
 		})
 	}
 }
+
+func TestDealServiceOp_CreateWithAssociations(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"512","properties":{"dealname":"Custom data integrations"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`),
+	}
+	associations := []hubspot.CreateAssociation{
+		{
+			To: hubspot.AssociationTo{ID: "company001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDDealToCompany},
+			},
+		},
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "512",
+		Properties: &hubspot.Deal{DealName: hubspot.NewString("Custom data integrations")},
+		CreatedAt:  &createdAt,
+		UpdatedAt:  &updatedAt,
+	}
+	wantBody := `{"properties":{"dealname":"Custom data integrations"},"associations":[{"to":{"id":"company001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":341}]}]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Deal.CreateWithAssociations(&hubspot.Deal{DealName: hubspot.NewString("Custom data integrations")}, associations)
+	if err != nil {
+		t.Fatalf("CreateWithAssociations() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("CreateWithAssociations() response mismatch (-want +got):%s", diff)
+	}
+	if got := string(conf.Requests[0].Body); got != wantBody {
+		t.Errorf("CreateWithAssociations() request body mismatch: want %s got %s", wantBody, got)
+	}
+}
+
+func TestDealServiceOp_SumProperty(t *testing.T) {
+	newHTTPClient := func(responses map[string]string, bodies *[]string) *http.Client {
+		return &http.Client{
+			Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+				body, _ := ioutil.ReadAll(req.Body)
+				*bodies = append(*bodies, string(body))
+				page := &hubspot.RequestSearchOption{}
+				_ = json.Unmarshal(body, page)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(responses[page.After])),
+					Header:     http.Header{},
+				}
+			}),
+		}
+	}
+	option := (&hubspot.RequestSearchOption{Properties: []string{"dealname"}}).
+		AddFilterGroup(hubspot.Filter{PropertyName: "dealstage", Operator: hubspot.FilterOperatorNotEqual, Value: "closedlost"})
+
+	t.Run("Sum the values of every page", func(t *testing.T) {
+		responses := map[string]string{
+			"":  `{"total":4,"results":[{"id":"1","properties":{"amount":"1500.50"}},{"id":"2","properties":{"amount":null}}],"paging":{"next":{"after":"2"}}}`,
+			"2": `{"total":4,"results":[{"id":"3","properties":{"amount":"2000"}},{"id":"4","properties":{"amount":""}}]}`,
+		}
+		var bodies []string
+		got, err := hubspot.NewMockClientWithHTTPClient(newHTTPClient(responses, &bodies)).CRM.Deal.SumProperty(option, "amount")
+		if err != nil {
+			t.Fatalf("SumProperty() unexpected error: %s", err)
+		}
+		if want := 3500.5; got != want {
+			t.Errorf("SumProperty() mismatch: want %v got %v", want, got)
+		}
+		wantBodies := []string{
+			`{"filterGroups":[{"filters":[{"value":"closedlost","propertyName":"dealstage","operator":"NEQ"}]}],"properties":["amount"],"limit":200}`,
+			`{"filterGroups":[{"filters":[{"value":"closedlost","propertyName":"dealstage","operator":"NEQ"}]}],"properties":["amount"],"limit":200,"after":"2"}`,
+		}
+		if diff := cmp.Diff(wantBodies, bodies); diff != "" {
+			t.Errorf("SumProperty() request body mismatch (-want +got):%s", diff)
+		}
+		if diff := cmp.Diff([]string{"dealname"}, option.Properties); diff != "" {
+			t.Errorf("SumProperty() changed the option (-want +got):%s", diff)
+		}
+	})
+
+	t.Run("Failed with a value that is not a number", func(t *testing.T) {
+		responses := map[string]string{"": `{"total":1,"results":[{"id":"1","properties":{"amount":"a lot"}}]}`}
+		var bodies []string
+		if _, err := hubspot.NewMockClientWithHTTPClient(newHTTPClient(responses, &bodies)).CRM.Deal.SumProperty(nil, "amount"); err == nil {
+			t.Error("SumProperty() error mismatch: want error got nil")
+		}
+	})
+
+	t.Run("Failed with more deals than search returns", func(t *testing.T) {
+		responses := map[string]string{"": `{"total":10001,"results":[{"id":"1","properties":{"amount":"1"}}],"paging":{"next":{"after":"1"}}}`}
+		var bodies []string
+		if _, err := hubspot.NewMockClientWithHTTPClient(newHTTPClient(responses, &bodies)).CRM.Deal.SumProperty(nil, "amount"); err == nil {
+			t.Error("SumProperty() error mismatch: want error got nil")
+		}
+		if len(bodies) != 1 {
+			t.Errorf("SumProperty() requests mismatch: want 1 got %d", len(bodies))
+		}
+	})
+}
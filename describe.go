@@ -0,0 +1,94 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// hsTypes are the types that decode the string values returned by HubSpot and encode them as HubSpot expects.
+var hsTypes = map[reflect.Type]bool{
+	reflect.TypeOf(HsStr("")):     true,
+	reflect.TypeOf(HsBool(false)): true,
+	reflect.TypeOf(HsInt(0)):      true,
+	reflect.TypeOf(HsFloat(0)):    true,
+	reflect.TypeOf(HsTime{}):      true,
+}
+
+// ValidateProperties checks a struct of properties, such as one embedding hubspot.Company, for the common mistakes
+// that make HubSpot silently drop or reject the properties.
+// It reports the exported fields without a hubspot or json tag, which are sent with the Go field name instead of the property name,
+// and the fields whose type is not one of the Hs* types, which fail to decode the string values returned by HubSpot.
+// Embedded structs are checked as well. This is intended to be called at development time, e.g. in a test.
+func ValidateProperties(v interface{}) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("invalid properties: %T is not a struct", v)
+	}
+	var problems []string
+	validatePropertyFields(t, &problems)
+	if len(problems) != 0 {
+		return fmt.Errorf("invalid properties of %s: %s", t, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validatePropertyFields(t reflect.Type, problems *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			// Unexported fields are not encoded.
+			continue
+		}
+		tag, hasTag := f.Tag.Lookup("json")
+		if hsTag, ok := f.Tag.Lookup(propertyTag); ok {
+			tag, hasTag = hsTag, true
+		}
+		if tag == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		// Embedded structs without a name are flattened by encoding/json.
+		if f.Anonymous && ft.Kind() == reflect.Struct && (!hasTag || strings.Split(tag, ",")[0] == "") {
+			validatePropertyFields(ft, problems)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if !hasTag || strings.Split(tag, ",")[0] == "" {
+			*problems = append(*problems, fmt.Sprintf("field %s has no json tag, it is sent as the property %q", f.Name, f.Name))
+		}
+		if !hsTypes[ft] {
+			*problems = append(*problems, fmt.Sprintf("field %s is %s, use one of the Hs* types such as *hubspot.HsStr", f.Name, f.Type))
+		}
+	}
+}
+
+// Describe returns the names of the properties the value would send to HubSpot as it is, in alphabetical order.
+// The fields omitted by omitempty are not included, so it shows which properties a Create or Update would set.
+func Describe(v interface{}) ([]string, error) {
+	b, err := json.Marshal(withPropertyMapping(v))
+	if err != nil {
+		return nil, err
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return nil, errors.New("unable to describe the properties: the value is not encoded as a JSON object")
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,63 @@
+package hubspot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestValidateProperties(t *testing.T) {
+	type customCompany struct {
+		hubspot.Company
+		TrialStatus *hubspot.HsStr `json:"trial_status,omitempty"`
+		Seats       *hubspot.HsInt `json:"seats,omitempty"`
+		internal    string
+	}
+	type brokenCompany struct {
+		hubspot.Company
+		TrialStatus *hubspot.HsStr
+		Seats       int `json:"seats"`
+		Ignored     string `json:"-"`
+	}
+
+	if err := hubspot.ValidateProperties(&customCompany{}); err != nil {
+		t.Errorf("ValidateProperties() unexpected error: %s", err)
+	}
+
+	err := hubspot.ValidateProperties(&brokenCompany{})
+	if err == nil {
+		t.Fatal("ValidateProperties() error mismatch: want error got nil")
+	}
+	for _, want := range []string{"field TrialStatus has no json tag", "field Seats is int"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateProperties() error mismatch: want %q in %q", want, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "Ignored") {
+		t.Errorf("ValidateProperties() error mismatch: want no error for the ignored field in %q", err.Error())
+	}
+
+	if err := hubspot.ValidateProperties("company"); err == nil {
+		t.Error("ValidateProperties() error mismatch: want error for a non-struct got nil")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	company := &struct {
+		hubspot.Company
+		TrialStatus *hubspot.HsStr `json:"trial_status,omitempty"`
+	}{
+		Company:     hubspot.Company{Name: hubspot.NewString("Acme"), Domain: hubspot.NewString("example.com")},
+		TrialStatus: hubspot.NewString("active"),
+	}
+
+	got, err := hubspot.Describe(company)
+	if err != nil {
+		t.Fatalf("Describe() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"domain", "name", "trial_status"}, got); diff != "" {
+		t.Errorf("Describe() response mismatch (-want +got):%s", diff)
+	}
+}
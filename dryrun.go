@@ -0,0 +1,92 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DryRunError is returned instead of sending a write when dry run is enabled with WithDryRun.
+// It holds the request that would have been sent, and matches ErrDryRun with errors.Is.
+type DryRunError struct {
+	Method string
+	URL    string
+	// Body is the JSON body of the request, after the properties are transformed by WithPropertyTransformer.
+	Body []byte
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("hubspot: dry run: %s %s", e.Method, e.URL)
+}
+
+// Is makes DryRunError matched by errors.Is(err, hubspot.ErrDryRun).
+func (e *DryRunError) Is(target error) bool {
+	return target == ErrDryRun
+}
+
+// isWriteRequest reports whether the request writes to HubSpot.
+// The search and batch read endpoints are POST requests that only read, so they are sent even in dry run.
+func isWriteRequest(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return false
+	}
+	path := req.URL.Path
+	return !strings.HasSuffix(path, "/search") && !strings.HasSuffix(path, "/"+batchBasePath+"/read")
+}
+
+// newDryRunError returns the DryRunError of the request, reading its body without consuming it.
+func newDryRunError(req *http.Request) error {
+	dryRunErr := &DryRunError{Method: req.Method, URL: req.URL.String()}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		if dryRunErr.Body, err = io.ReadAll(body); err != nil {
+			return err
+		}
+	}
+	return dryRunErr
+}
+
+// payloadProperties returns the names of the properties set in the request body, in the "properties" of the payload
+// and in those of the inputs of a batch request.
+func payloadProperties(data interface{}) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Inputs     []struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"inputs"`
+	}
+	// Bodies of other shapes, such as a list of IDs, have no properties to check.
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, nil
+	}
+	var names []string
+	seen := map[string]bool{}
+	add := func(properties map[string]json.RawMessage) {
+		for name := range properties {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	add(payload.Properties)
+	for _, input := range payload.Inputs {
+		add(input.Properties)
+	}
+	sort.Strings(names)
+	return names, nil
+}
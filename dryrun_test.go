@@ -0,0 +1,105 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"bendingspoons.com/hubspot"
+)
+
+func TestWithDryRun(t *testing.T) {
+	var requests []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			body := `{"total":1,"results":[{"id":"company001","properties":{"name":"Acme"}}]}`
+			if strings.HasPrefix(req.URL.Path, "/crm/v3/properties/") {
+				body = companyPropertiesBody
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+
+	t.Run("Stop the writes", func(t *testing.T) {
+		requests = nil
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		if err := hubspot.WithDryRun()(c); err != nil {
+			t.Fatalf("WithDryRun() unexpected error: %s", err)
+		}
+
+		_, err := c.CRM.Company.Update("company001", &hubspot.Company{Name: hubspot.NewString("Acme")})
+		var dryRunErr *hubspot.DryRunError
+		if !errors.As(err, &dryRunErr) || !errors.Is(err, hubspot.ErrDryRun) {
+			t.Fatalf("Update() error mismatch: want DryRunError got %v", err)
+		}
+		if dryRunErr.Method != http.MethodPatch || !strings.HasSuffix(dryRunErr.URL, "/crm/v3/objects/companies/company001") {
+			t.Errorf("Update() dry run request mismatch: want PATCH .../crm/v3/objects/companies/company001 got %s %s", dryRunErr.Method, dryRunErr.URL)
+		}
+		if want := `{"properties":{"name":"Acme"}}`; string(dryRunErr.Body) != want {
+			t.Errorf("Update() dry run body mismatch: want %s got %s", want, string(dryRunErr.Body))
+		}
+		if err := c.CRM.Company.Delete("company001"); !errors.Is(err, hubspot.ErrDryRun) {
+			t.Errorf("Delete() error mismatch: want ErrDryRun got %v", err)
+		}
+		upload := &hubspot.FileUpload{Name: "contract.pdf", Content: strings.NewReader("%PDF-1.4"), FolderPath: "/contracts"}
+		if _, err := c.Files.File.Upload(upload); !errors.Is(err, hubspot.ErrDryRun) {
+			t.Errorf("Upload() error mismatch: want ErrDryRun got %v", err)
+		}
+		if len(requests) != 0 {
+			t.Errorf("requests mismatch: want no requests got %v", requests)
+		}
+	})
+
+	t.Run("Send the reads", func(t *testing.T) {
+		requests = nil
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		if err := hubspot.WithDryRun()(c); err != nil {
+			t.Fatalf("WithDryRun() unexpected error: %s", err)
+		}
+
+		if _, err := c.CRM.Company.Search(&hubspot.Company{}, &hubspot.RequestSearchOption{}); err != nil {
+			t.Errorf("Search() unexpected error: %s", err)
+		}
+		if _, err := c.CRM.Company.Get("company001", &hubspot.Company{}, nil); err != nil {
+			t.Errorf("Get() unexpected error: %s", err)
+		}
+		if len(requests) != 2 {
+			t.Errorf("requests mismatch: want 2 requests got %v", requests)
+		}
+	})
+
+	t.Run("Check the properties of the writes", func(t *testing.T) {
+		type CustomCompany struct {
+			hubspot.Company
+			TrialStatus *hubspot.HsStr `json:"trial_statuss,omitempty"`
+		}
+
+		requests = nil
+		c := hubspot.NewMockClientWithHTTPClient(httpClient)
+		for _, opt := range []hubspot.Option{hubspot.WithDryRun(), hubspot.WithPropertyValidation(time.Minute)} {
+			if err := opt(c); err != nil {
+				t.Fatalf("option unexpected error: %s", err)
+			}
+		}
+
+		if _, err := c.CRM.Company.Create(&hubspot.Company{Name: hubspot.NewString("Acme")}); !errors.Is(err, hubspot.ErrDryRun) {
+			t.Errorf("Create() error mismatch: want ErrDryRun got %v", err)
+		}
+		_, err := c.CRM.Company.Create(&CustomCompany{TrialStatus: hubspot.NewString("active")})
+		if !errors.Is(err, hubspot.ErrUnknownProperty) || !strings.Contains(err.Error(), "trial_statuss") {
+			t.Errorf("Create() error mismatch: want ErrUnknownProperty of trial_statuss got %v", err)
+		}
+		if want := []string{"GET /crm/v3/properties/companies"}; len(requests) != 1 || requests[0] != want[0] {
+			t.Errorf("requests mismatch: want %v got %v", want, requests)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package hubspot
+
+const (
+	emailBasePath = "emails"
+)
+
+// EmailService is an interface of email engagement endpoints of the HubSpot API.
+// HubSpot email engagements log emails sent to or received from contacts.
+// It can also be associated with other CRM objects such as contact, company and deal.
+// Reference: https://developers.hubspot.com/docs/api/crm/email
+type EmailService interface {
+	Get(emailID string, email interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	Create(email interface{}) (*ResponseResource, error)
+	CreateWithAssociations(email interface{}, associations []CreateAssociation) (*ResponseResource, error)
+	Update(emailID string, email interface{}) (*ResponseResource, error)
+	Delete(emailID string) error
+}
+
+// EmailServiceOp handles communication with the email engagement related methods of the HubSpot API.
+type EmailServiceOp struct {
+	emailPath string
+	client    *Client
+}
+
+var _ EmailService = (*EmailServiceOp)(nil)
+
+// Email directions
+const (
+	EmailDirectionOutgoing  = "EMAIL"
+	EmailDirectionIncoming  = "INCOMING_EMAIL"
+	EmailDirectionForwarded = "FORWARDED_EMAIL"
+)
+
+// Email statuses
+const (
+	EmailStatusBounced   = "BOUNCED"
+	EmailStatusFailed    = "FAILED"
+	EmailStatusScheduled = "SCHEDULED"
+	EmailStatusSending   = "SENDING"
+	EmailStatusSent      = "SENT"
+)
+
+// Email represents a HubSpot email engagement.
+// HsEmailDirection is one of the EmailDirection constants, and HsEmailStatus is one of the EmailStatus constants.
+type Email struct {
+	HsTimestamp        *HsTime `json:"hs_timestamp,omitempty"`
+	HsEmailDirection   *HsStr  `json:"hs_email_direction,omitempty"`
+	HsEmailSubject     *HsStr  `json:"hs_email_subject,omitempty"`
+	HsEmailText        *HsStr  `json:"hs_email_text,omitempty"`
+	HsEmailHTML        *HsStr  `json:"hs_email_html,omitempty"`
+	HsEmailStatus      *HsStr  `json:"hs_email_status,omitempty"`
+	HubspotOwnerID     *HsStr  `json:"hubspot_owner_id,omitempty"`
+	HsObjectID         *HsStr  `json:"hs_object_id,omitempty"`
+	HsCreateDate       *HsTime `json:"hs_createdate,omitempty"`
+	HsLastModifiedDate *HsTime `json:"hs_lastmodifieddate,omitempty"`
+}
+
+var defaultEmailFields = []string{
+	"hs_timestamp",
+	"hs_email_direction",
+	"hs_email_subject",
+	"hs_email_text",
+	"hs_email_html",
+	"hs_email_status",
+	"hubspot_owner_id",
+	"hs_object_id",
+	"hs_createdate",
+	"hs_lastmodifieddate",
+}
+
+// Get gets an email engagement.
+// In order to bind the get content, a structure must be specified as an argument.
+// Also, if you want to gets a custom field, you need to specify the field name.
+// If you specify a non-existent field, it will be ignored.
+// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+func (s *EmailServiceOp) Get(emailID string, email interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: email}
+	if err := s.client.Get(s.emailPath+"/"+emailID, resource, option.setupProperties(defaultEmailFields)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Create creates a new email engagement.
+// In order to bind the created content, a structure must be specified as an argument.
+// When using custom fields, please embed hubspot.Email in your own structure.
+// If hs_timestamp is not set, it is set to the current time, as HubSpot rejects the engagements without it.
+// Set HsTimestamp to log an engagement that happened at another time.
+func (s *EmailServiceOp) Create(email interface{}) (*ResponseResource, error) {
+	return s.CreateWithAssociations(email, nil)
+}
+
+// CreateWithAssociations creates a new email engagement and associates it with other objects in the same request.
+// In order to bind the created content, a structure must be specified as an argument.
+// hs_timestamp defaults to the current time in the same way as Create.
+func (s *EmailServiceOp) CreateWithAssociations(email interface{}, associations []CreateAssociation) (*ResponseResource, error) {
+	properties, err := withDefaultTimestamp(email)
+	if err != nil {
+		return nil, err
+	}
+	req := &RequestPayload{Properties: properties, Associations: associations}
+	resource := &ResponseResource{Properties: email}
+	if err := s.client.Post(s.emailPath, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Update updates an email engagement.
+// In order to bind the updated content, a structure must be specified as an argument.
+// When using custom fields, please embed hubspot.Email in your own structure.
+func (s *EmailServiceOp) Update(emailID string, email interface{}) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: email}
+	resource := &ResponseResource{Properties: email}
+	if err := s.client.Patch(s.emailPath+"/"+emailID, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Delete deletes an email engagement.
+func (s *EmailServiceOp) Delete(emailID string) error {
+	if err := s.client.Delete(s.emailPath + "/" + emailID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestEmailServiceOp_CreateWithAssociations(t *testing.T) {
+	email := &hubspot.Email{
+		HsTimestamp:      hubspot.NewTime(time.Date(2019, 10, 30, 3, 30, 17, 883000000, time.UTC)),
+		HsEmailDirection: hubspot.NewString(hubspot.EmailDirectionOutgoing),
+		HsEmailSubject:   hubspot.NewString("Welcome"),
+		HsEmailText:      hubspot.NewString("Thanks for signing up."),
+		HsEmailStatus:    hubspot.NewString(hubspot.EmailStatusSent),
+	}
+	associations := []hubspot.CreateAssociation{
+		{
+			To: hubspot.AssociationTo{ID: "contact001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDEmailToContact},
+			},
+		},
+	}
+
+	type args struct {
+		email        interface{}
+		associations []hubspot.CreateAssociation
+	}
+	tests := []struct {
+		name     string
+		conf     *hubspot.MockConfig
+		args     args
+		want     *hubspot.ResponseResource
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name: "Successfully create an email with associations",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusCreated,
+				Header: http.Header{},
+				Body:   []byte(`{"id":"email001","properties":{"hs_createdate":"2019-10-30T03:30:17.883Z","hs_email_direction":"EMAIL","hs_email_status":"SENT","hs_email_subject":"Welcome","hs_email_text":"Thanks for signing up.","hs_lastmodifieddate":"2019-12-07T16:50:06.678Z","hs_object_id":"email001","hs_timestamp":"2019-10-30T03:30:17.883Z"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`),
+			},
+			args: args{
+				email:        email,
+				associations: associations,
+			},
+			want: &hubspot.ResponseResource{
+				ID:       "email001",
+				Archived: false,
+				Properties: &hubspot.Email{
+					HsTimestamp:        &createdAt,
+					HsEmailDirection:   hubspot.NewString(hubspot.EmailDirectionOutgoing),
+					HsEmailSubject:     hubspot.NewString("Welcome"),
+					HsEmailText:        hubspot.NewString("Thanks for signing up."),
+					HsEmailStatus:      hubspot.NewString(hubspot.EmailStatusSent),
+					HsObjectID:         hubspot.NewString("email001"),
+					HsCreateDate:       &createdAt,
+					HsLastModifiedDate: &updatedAt,
+				},
+				CreatedAt: &createdAt,
+				UpdatedAt: &updatedAt,
+			},
+			wantBody: `{"properties":{"hs_timestamp":"2019-10-30T03:30:17.883Z","hs_email_direction":"EMAIL","hs_email_subject":"Welcome","hs_email_text":"Thanks for signing up.","hs_email_status":"SENT"},"associations":[{"to":{"id":"contact001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":198}]}]}`,
+			wantErr:  nil,
+		},
+		{
+			name: "Received invalid request, with hs_timestamp defaulted to the current time",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusBadRequest,
+				Header: http.Header{},
+				Body:   []byte(`{"message": "Invalid input (details will vary based on the error)","correlationId": "aeb5f871-7f07-4993-9211-075dc63e7cbf","category": "VALIDATION_ERROR"}`),
+			},
+			args: args{
+				email: &hubspot.Email{},
+			},
+			want:     nil,
+			wantBody: `{"properties":{"hs_timestamp":"2020-12-31T12:00:00Z"}}`,
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode: http.StatusBadRequest,
+				Message:        "Invalid input (details will vary based on the error)",
+				CorrelationID:  "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				Category:       hubspot.ValidationError,
+			},
+		},
+	}
+	defer hubspot.MockTimeNow()()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubspot.NewMockClient(tt.conf)
+			got, err := c.CRM.Email.CreateWithAssociations(tt.args.email, tt.args.associations)
+			if !reflect.DeepEqual(tt.wantErr, err) {
+				t.Errorf("CreateWithAssociations() error mismatch: want %s got %s", tt.wantErr, err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpTimeOption); diff != "" {
+				t.Errorf("CreateWithAssociations() response mismatch (-want +got):%s", diff)
+			}
+			if got := string(tt.conf.Requests[0].Body); got != tt.wantBody {
+				t.Errorf("CreateWithAssociations() request body mismatch: want %s got %s", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestEmailServiceOp_Get(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"email001","properties":{"hs_email_direction":"INCOMING_EMAIL","hs_email_subject":"Re: Welcome","hs_object_id":"email001","hs_timestamp":"2019-10-30T03:30:17.883Z"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID: "email001",
+		Properties: &hubspot.Email{
+			HsTimestamp:      &createdAt,
+			HsEmailDirection: hubspot.NewString(hubspot.EmailDirectionIncoming),
+			HsEmailSubject:   hubspot.NewString("Re: Welcome"),
+			HsObjectID:       hubspot.NewString("email001"),
+		},
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Email.Get("email001", &hubspot.Email{}, nil)
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	if got, want := conf.Requests[0].URL.Path, "/crm/v3/objects/emails/email001"; got != want {
+		t.Errorf("Get() request path mismatch: want %s got %s", want, got)
+	}
+}
@@ -0,0 +1,29 @@
+package hubspot
+
+import "encoding/json"
+
+// engagementTimestampProperty is the property of the time an engagement happened, which HubSpot requires on create.
+const engagementTimestampProperty = "hs_timestamp"
+
+// withDefaultTimestamp returns the properties of an engagement to send on create, with hs_timestamp set to the current
+// time if it is not set, as HubSpot rejects the engagements created without it. An hs_timestamp set by the caller is kept.
+func withDefaultTimestamp(engagement interface{}) (interface{}, error) {
+	b, err := json.Marshal(withPropertyMapping(engagement))
+	if err != nil {
+		return nil, err
+	}
+	properties := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	if raw, ok := properties[engagementTimestampProperty]; ok && string(raw) != "null" && string(raw) != `""` {
+		return engagement, nil
+	}
+	if properties == nil {
+		properties = map[string]json.RawMessage{}
+	}
+	if properties[engagementTimestampProperty], err = json.Marshal(NewTime(timeNow())); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
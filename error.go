@@ -1,6 +1,12 @@
 package hubspot
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
 
 const (
 	// ValidationError is the APIError.Category.
@@ -14,6 +20,83 @@ const (
 	UnknownDetailError = "UNKNOWN_DETAIL"
 )
 
+// Rate limit policies, the value of APIError.PolicyName.
+const (
+	RateLimitPolicySecondly           = "SECONDLY"
+	RateLimitPolicyTenSecondlyRolling = "TEN_SECONDLY_ROLLING"
+	RateLimitPolicyDaily              = "DAILY"
+)
+
+// ErrNotFound is matched by errors.Is when HubSpot responds with 404 Not Found.
+// This is returned when the requested object does not exist, e.g. Get with an unknown ID.
+var ErrNotFound = errors.New("hubspot: not found")
+
+// ErrConflict is matched by errors.Is when HubSpot responds with 409 Conflict.
+// This is returned when creating an object whose unique property value already exists.
+// The ID of the existing object is available in APIError.ExistingObjectID.
+var ErrConflict = errors.New("hubspot: conflict")
+
+// ErrGone is matched by errors.Is when HubSpot responds with 410 Gone.
+// This is returned when the requested resource has been permanently removed, e.g. a merged or purged object.
+var ErrGone = errors.New("hubspot: gone")
+
+// ErrUnauthorized is matched by errors.Is when HubSpot responds with 401 Unauthorized.
+// This is returned when the API key or access token is invalid or expired.
+var ErrUnauthorized = errors.New("hubspot: unauthorized")
+
+// ErrForbidden is matched by errors.Is when HubSpot responds with 403 Forbidden.
+// This is returned when the credentials are valid but lack the scopes required by the endpoint.
+var ErrForbidden = errors.New("hubspot: forbidden")
+
+// ErrRateLimited is matched by errors.Is when HubSpot responds with 429 Too Many Requests.
+var ErrRateLimited = errors.New("hubspot: rate limited")
+
+// ErrDailyLimitExceeded is matched by errors.Is when HubSpot responds with 429 Too Many Requests because the daily limit
+// of the account is exhausted, in which case retrying does not help until the next day.
+// It is matched in addition to ErrRateLimited.
+var ErrDailyLimitExceeded = errors.New("hubspot: daily limit exceeded")
+
+// ErrUnknownProperty is returned when property validation is enabled with WithPropertyValidation
+// and a requested property is not defined for the object type.
+var ErrUnknownProperty = errors.New("hubspot: unknown property")
+
+// ErrInvalidLimit is returned without making the request when RequestQueryOption.Limit is more than the endpoint accepts.
+var ErrInvalidLimit = errors.New("hubspot: invalid limit")
+
+// ErrSearchNotIndexed is returned when a search with RequestSearchOption.ExpectResults still has no result after
+// the retries, e.g. because HubSpot has not indexed the objects written just before yet.
+var ErrSearchNotIndexed = errors.New("hubspot: search results not indexed")
+
+// ErrInvalidSortDirection is returned without making the request when a Sort.Direction of RequestSearchOption
+// is neither SortDirectionAscending nor SortDirectionDescending.
+var ErrInvalidSortDirection = errors.New("hubspot: invalid sort direction")
+
+// ErrUnreachable is returned by Client.Ping when HubSpot cannot be reached, e.g. on a DNS or connection failure.
+var ErrUnreachable = errors.New("hubspot: unreachable")
+
+// ErrDryRun is matched by errors.Is when a write is not sent because dry run is enabled with WithDryRun.
+// The request that would have been sent is available in *DryRunError with errors.As.
+var ErrDryRun = errors.New("hubspot: dry run")
+
+// ErrArchiveNotConfirmed is returned without making any request when a batch archive has more objects than the limit
+// set by WithBatchArchiveLimit, 10 by default, and BatchArchiveOption.Force is not set.
+var ErrArchiveNotConfirmed = errors.New("hubspot: archive not confirmed")
+
+// ErrUnknownField is returned when strict decoding is enabled with WithStrictDecoding and a response has a field,
+// including a property, that the structure it is decoded into has no field for.
+var ErrUnknownField = errors.New("hubspot: unknown field")
+
+// ErrFieldAlreadySet is returned by UpdateFieldIfEmpty when the property already has a value, which is kept as it is.
+var ErrFieldAlreadySet = errors.New("hubspot: field already set")
+
+// ErrPagingLinkHost is returned when HubSpot returns the link of the next page on another host than the base URL
+// of the client, so that neither the cursor nor the next request is taken from it.
+var ErrPagingLinkHost = errors.New("hubspot: paging link to another host")
+
+// existingIDPattern matches the ID of the existing object in the message of a conflict error.
+// e.g. "Contact already exists. Existing ID: 512"
+var existingIDPattern = regexp.MustCompile(`(?i)existing (?:object )?id:?\s*(\d+)`)
+
 type APIError struct {
 	HTTPStatusCode int         `json:"-"`
 	Status         string      `json:"status,omitempty"`
@@ -24,6 +107,17 @@ type APIError struct {
 	SubCategory    string      `json:"subCategory,omitempty"`
 	Links          ErrLinks    `json:"links,omitempty"`
 	Details        []ErrDetail `json:"details,omitempty"`
+
+	// ExistingObjectID is the ID of the existing object, set when HubSpot responds with 409 Conflict.
+	ExistingObjectID string `json:"-"`
+	// RetryAfter is how long to wait before retrying, set when HubSpot responds with 429 Too Many Requests
+	// and a Retry-After header in either form, delay seconds or HTTP-date.
+	RetryAfter time.Duration `json:"-"`
+	// HasRetryAfter reports whether RetryAfter was sent by HubSpot, so that a zero RetryAfter means retrying right away.
+	HasRetryAfter bool `json:"-"`
+	// PolicyName is the rate limit hit when HubSpot responds with 429 Too Many Requests, such as RateLimitPolicyDaily.
+	// It is set to RateLimitPolicyDaily when HubSpot reports no remaining daily requests even if the body has no policy.
+	PolicyName string `json:"policyName,omitempty"`
 }
 
 type ErrDetail struct {
@@ -34,11 +128,15 @@ type ErrDetail struct {
 }
 
 type ErrContext struct {
-	ID             []string `json:"id,omitempty"`
+	ID []string `json:"id,omitempty"`
+	// IDs is the IDs of the failed inputs of a batch request.
+	IDs            []string `json:"ids,omitempty"`
 	Type           []string `json:"type,omitempty"`
 	ObjectType     []string `json:"objectType,omitempty"`
 	FromObjectType []string `json:"fromObjectType,omitempty"`
 	ToObjectType   []string `json:"toObjectType,omitempty"`
+	// ObjectWriteTraceID is the objectWriteTraceId of the failed inputs of a batch write, set by WithWriteSource.
+	ObjectWriteTraceID []string `json:"objectWriteTraceId,omitempty"`
 }
 
 type ErrLinks struct {
@@ -49,3 +147,38 @@ type ErrLinks struct {
 func (e APIError) Error() string {
 	return fmt.Sprintf("%d: %s", e.HTTPStatusCode, e.Message)
 }
+
+// Is reports whether the error matches the target sentinel error of its HTTP status,
+// one of ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrGone and ErrRateLimited.
+// e.g. errors.Is(err, hubspot.ErrNotFound)
+// The APIError itself, with the details of the error, is still available with errors.As.
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.HTTPStatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.HTTPStatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.HTTPStatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.HTTPStatusCode == http.StatusConflict
+	case ErrGone:
+		return e.HTTPStatusCode == http.StatusGone
+	case ErrRateLimited:
+		return e.HTTPStatusCode == http.StatusTooManyRequests
+	case ErrDailyLimitExceeded:
+		return e.HTTPStatusCode == http.StatusTooManyRequests && e.PolicyName == RateLimitPolicyDaily
+	default:
+		return false
+	}
+}
+
+// extractExistingObjectID extracts the ID of the existing object from the message of a conflict error.
+// If the message does not contain the ID, it returns an empty string.
+func extractExistingObjectID(message string) string {
+	m := existingIDPattern.FindStringSubmatch(message)
+	if len(m) < 2 {
+		return ""
+	}
+	return m[1]
+}
@@ -143,7 +143,7 @@ func ExampleContactServiceOp_AssociateAnotherObj() {
 }
 
 type ExampleDeal struct {
-	amount  string
+	amount  float64
 	name    string
 	stage   string
 	ownerID string
@@ -153,14 +153,14 @@ func ExampleDealServiceOp_Create_apikey() {
 	cli, _ := hubspot.NewClient(hubspot.SetAPIKey("apikey"))
 
 	example := &ExampleDeal{
-		amount:  "1500.00",
+		amount:  1500,
 		name:    "Custom data integrations",
 		stage:   "presentation scheduled",
 		ownerID: "910901",
 	}
 
 	deal := &hubspot.Deal{
-		Amount:      hubspot.NewString(example.amount),
+		Amount:      hubspot.NewFloat(example.amount),
 		DealName:    hubspot.NewString(example.name),
 		DealStage:   hubspot.NewString(example.stage),
 		DealOwnerID: hubspot.NewString(example.ownerID),
@@ -194,14 +194,14 @@ func ExampleDealServiceOp_Create_oauth() {
 	}))
 
 	example := &ExampleDeal{
-		amount:  "1500.00",
+		amount:  1500,
 		name:    "Custom data integrations",
 		stage:   "presentation scheduled",
 		ownerID: "910901",
 	}
 
 	deal := &hubspot.Deal{
-		Amount:      hubspot.NewString(example.amount),
+		Amount:      hubspot.NewFloat(example.amount),
 		DealName:    hubspot.NewString(example.name),
 		DealStage:   hubspot.NewString(example.stage),
 		DealOwnerID: hubspot.NewString(example.ownerID),
@@ -236,7 +236,7 @@ func ExampleDealServiceOp_Create_custom() {
 	cli, _ := hubspot.NewClient(hubspot.SetAPIKey("apikey"))
 
 	example := &ExampleDeal{
-		amount:  "1500.00",
+		amount:  1500,
 		name:    "Custom data integrations",
 		stage:   "presentation scheduled",
 		ownerID: "910901",
@@ -245,7 +245,7 @@ func ExampleDealServiceOp_Create_custom() {
 	// Take advantage of structure embedding when using custom fields.
 	deal := &CustomDeal{
 		Deal: hubspot.Deal{
-			Amount:      hubspot.NewString(example.amount),
+			Amount:      hubspot.NewFloat(example.amount),
 			DealName:    hubspot.NewString(example.name),
 			DealStage:   hubspot.NewString(example.stage),
 			DealOwnerID: hubspot.NewString(example.ownerID),
@@ -275,14 +275,14 @@ func ExampleDealServiceOp_Update() {
 	cli, _ := hubspot.NewClient(hubspot.SetAPIKey("apikey"))
 
 	example := &ExampleDeal{
-		amount:  "1500.00",
+		amount:  1500,
 		name:    "Custom data integrations",
 		stage:   "presentation scheduled",
 		ownerID: "910901",
 	}
 
 	deal := &hubspot.Deal{
-		Amount:      hubspot.NewString(example.amount),
+		Amount:      hubspot.NewFloat(example.amount),
 		DealName:    hubspot.NewString(example.name),
 		DealStage:   hubspot.NewString(example.stage),
 		DealOwnerID: hubspot.NewString(example.ownerID),
@@ -7,17 +7,37 @@ import "net/url"
 var (
 	ExportAPIVersion = defaultAPIVersion
 
-	ExportBaseURL          = defaultBaseURL
-	ExportContactBasePath  = contactBasePath
-	ExportDealBasePath     = dealBasePath
-	ExportOwnerBasePath    = ownerBasePath
-	ExportPipelineBasePath = pipelineBasePath
+	ExportBaseURL                    = defaultBaseURL
+	ExportContactBasePath            = contactBasePath
+	ExportDealBasePath               = dealBasePath
+	ExportEmailBasePath              = emailBasePath
+	ExportFeedbackSubmissionBasePath = feedbackSubmissionBasePath
+	ExportListBasePath               = listBasePath
+	ExportMeetingBasePath            = meetingBasePath
+	ExportCallBasePath               = callBasePath
+	ExportOwnerBasePath              = ownerBasePath
+	ExportPipelineBasePath           = pipelineBasePath
+
+	ExportDefaultCompanyFields = defaultCompanyFields
 )
 
 var (
-	ExportNewCRM = newCRM
+	ExportNewCRM       = newCRM
+	ExportNewFiles     = newFiles
+	ExportNewMarketing = newMarketing
+
+	ExportRateLimitBackoff   = &rateLimitBackoff
+	ExportRateLimitInterval  = &rateLimitInterval
+	ExportSearchIndexBackoff = &searchIndexBackoff
+	ExportTimeNow            = &timeNow
+
+	ExportTemplatePath    = templatePath
+	ExportParseRetryAfter = parseRetryAfter
+	ExportRetryBackoff    = retryBackoff
+	ExportMaxRetryAfter   = maxRetryAfter
 
-	ExportSetupProperties = (*RequestQueryOption).setupProperties
+	ExportSetupProperties       = (*RequestQueryOption).setupProperties
+	ExportSearchSetupProperties = (*RequestSearchOption).setupProperties
 
 	ExportFetchTokenFromHubSpot = (*OAuthTokenManager).fetchTokenFromHubSpot
 	ExportRefreshToken          = (*OAuthTokenManager).refreshToken
@@ -0,0 +1,117 @@
+package hubspot
+
+const (
+	feedbackSubmissionBasePath = "feedback_submissions"
+)
+
+// FeedbackSubmissionService is an interface of feedback submission endpoints of the HubSpot API.
+// HubSpot feedback submissions store the responses to NPS, CSAT and CES surveys.
+// They are created by HubSpot when a survey is answered, so only read operations are available.
+// Reference: https://developers.hubspot.com/docs/api/crm/feedback-submissions
+type FeedbackSubmissionService interface {
+	Get(feedbackSubmissionID string, feedbackSubmission interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetAll(feedbackSubmission interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error)
+	Search(feedbackSubmission interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error)
+}
+
+// FeedbackSubmissionServiceOp handles communication with the feedback submission related methods of the HubSpot API.
+type FeedbackSubmissionServiceOp struct {
+	feedbackSubmissionPath string
+	client                 *Client
+}
+
+var _ FeedbackSubmissionService = (*FeedbackSubmissionServiceOp)(nil)
+
+// Survey types
+const (
+	FeedbackSurveyTypeNPS  = "NPS"
+	FeedbackSurveyTypeCSAT = "CSAT"
+	FeedbackSurveyTypeCES  = "CES"
+)
+
+// Feedback sentiments
+const (
+	FeedbackSentimentPositive = "POSITIVE"
+	FeedbackSentimentNeutral  = "NEUTRAL"
+	FeedbackSentimentNegative = "NEGATIVE"
+)
+
+// Response groups of NPS feedback submissions, the value of FeedbackSubmission.HsResponseGroup.
+const (
+	FeedbackResponseGroupPromoter  = "PROMOTER"
+	FeedbackResponseGroupPassive   = "PASSIVE"
+	FeedbackResponseGroupDetractor = "DETRACTOR"
+)
+
+// FeedbackSubmission represents a HubSpot feedback submission.
+type FeedbackSubmission struct {
+	HsSurveyType          *HsStr  `json:"hs_survey_type,omitempty"`
+	HsSurveyID            *HsStr  `json:"hs_survey_id,omitempty"`
+	HsSurveyName          *HsStr  `json:"hs_survey_name,omitempty"`
+	HsValue               *HsInt  `json:"hs_value,omitempty"`
+	HsResponseGroup       *HsStr  `json:"hs_response_group,omitempty"`
+	HsSentiment           *HsStr  `json:"hs_sentiment,omitempty"`
+	HsContent             *HsStr  `json:"hs_content,omitempty"`
+	HsContactID           *HsStr  `json:"hs_contact_id,omitempty"`
+	HsSubmissionTimestamp *HsTime `json:"hs_submission_timestamp,omitempty"`
+	HsObjectID            *HsStr  `json:"hs_object_id,omitempty"`
+	HsCreateDate          *HsTime `json:"hs_createdate,omitempty"`
+	HsLastModifiedDate    *HsTime `json:"hs_lastmodifieddate,omitempty"`
+}
+
+var defaultFeedbackSubmissionFields = []string{
+	"hs_survey_type",
+	"hs_survey_id",
+	"hs_survey_name",
+	"hs_value",
+	"hs_response_group",
+	"hs_sentiment",
+	"hs_content",
+	"hs_contact_id",
+	"hs_submission_timestamp",
+	"hs_object_id",
+	"hs_createdate",
+	"hs_lastmodifieddate",
+}
+
+// Get gets a feedback submission.
+// In order to bind the get content, a structure must be specified as an argument.
+// Also, if you want to gets a custom field, you need to specify the field name.
+// If you specify a non-existent field, it will be ignored.
+// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+func (s *FeedbackSubmissionServiceOp) Get(feedbackSubmissionID string, feedbackSubmission interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: feedbackSubmission}
+	if err := s.client.Get(s.feedbackSubmissionPath+"/"+feedbackSubmissionID, resource, option.setupProperties(defaultFeedbackSubmissionFields)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetAll gets a page of feedback submissions.
+// Use RequestQueryOption.Limit and RequestQueryOption.After to get the following pages.
+// The properties of each feedback submission are bound to a new structure of the same type as the argument.
+// If there is no feedback submission, an empty Results is returned without error.
+// RequestQueryOption.Limit is up to 100, and an error wrapping ErrInvalidLimit is returned for a larger one.
+func (s *FeedbackSubmissionServiceOp) GetAll(feedbackSubmission interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error) {
+	if err := option.validateLimit(maxListLimit); err != nil {
+		return nil, err
+	}
+	page := &pagedResponse{}
+	if err := s.client.Get(s.feedbackSubmissionPath, page, option.setupProperties(defaultFeedbackSubmissionFields)); err != nil {
+		return nil, err
+	}
+	return s.client.decodePage(page, feedbackSubmission)
+}
+
+// Search finds feedback submissions.
+// In order to bind the get content, a structure must be specified as an argument.
+// The default fields are requested in the same way as Get.
+// e.g. &hubspot.RequestSearchOption{ CustomProperties: []string{"custom_a", "custom_b"}}
+// An error is returned without making the request if the filter groups exceed the limits of HubSpot.
+func (s *FeedbackSubmissionServiceOp) Search(feedbackSubmission interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error) {
+	resource := &ResponseResourceMulti{}
+	if err := s.client.search(s.feedbackSubmissionPath, option.setupProperties(defaultFeedbackSubmissionFields), feedbackSubmission, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,92 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestFeedbackSubmissionServiceOp_GetAll(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"id":"fs001","properties":{"hs_survey_type":"NPS","hs_value":"9","hs_response_group":"PROMOTER","hs_sentiment":"POSITIVE","hs_submission_timestamp":"2019-10-30T03:30:17.883Z"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"},{"id":"fs002","properties":{"hs_survey_type":"CSAT","hs_value":"2","hs_sentiment":"NEGATIVE","hs_submission_timestamp":"2019-12-07T16:50:06.678Z"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}],"paging":{"next":{"after":"fs002"}}}`),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{
+			{
+				ID: "fs001",
+				Properties: &hubspot.FeedbackSubmission{
+					HsSurveyType:          hubspot.NewString(hubspot.FeedbackSurveyTypeNPS),
+					HsValue:               hubspot.NewInt(9),
+					HsResponseGroup:       hubspot.NewString(hubspot.FeedbackResponseGroupPromoter),
+					HsSentiment:           hubspot.NewString(hubspot.FeedbackSentimentPositive),
+					HsSubmissionTimestamp: &createdAt,
+				},
+				CreatedAt: &createdAt,
+				UpdatedAt: &updatedAt,
+			},
+			{
+				ID: "fs002",
+				Properties: &hubspot.FeedbackSubmission{
+					HsSurveyType:          hubspot.NewString(hubspot.FeedbackSurveyTypeCSAT),
+					HsValue:               hubspot.NewInt(2),
+					HsSentiment:           hubspot.NewString(hubspot.FeedbackSentimentNegative),
+					HsSubmissionTimestamp: &updatedAt,
+				},
+				CreatedAt: &createdAt,
+				UpdatedAt: &updatedAt,
+			},
+		},
+		Paging: &hubspot.Paging{Next: &hubspot.PagingNext{After: "fs002"}},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.FeedbackSubmission.GetAll(&hubspot.FeedbackSubmission{}, &hubspot.RequestQueryOption{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/feedback_submissions"; req.URL.Path != want {
+		t.Errorf("GetAll() request path mismatch: want %s got %s", want, req.URL.Path)
+	}
+	if got := req.URL.Query().Get("limit"); got != "2" {
+		t.Errorf("GetAll() limit mismatch: want 2 got %s", got)
+	}
+}
+
+func TestFeedbackSubmissionServiceOp_Search(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"total":1,"results":[{"id":"fs001","properties":{"hs_survey_type":"NPS","hs_value":"9"}}]}`),
+	}
+	option := (&hubspot.RequestSearchOption{Properties: []string{"hs_survey_type", "hs_value"}}).
+		AddFilterGroup(hubspot.Filter{PropertyName: "hs_survey_type", Operator: hubspot.FilterOperatorEqual, Value: hubspot.FeedbackSurveyTypeNPS})
+	want := &hubspot.ResponseResourceMulti{
+		Total: 1,
+		Results: []hubspot.ResponseResource{
+			{ID: "fs001", Properties: &hubspot.FeedbackSubmission{HsSurveyType: hubspot.NewString("NPS"), HsValue: hubspot.NewInt(9)}},
+		},
+	}
+	wantBody := `{"filterGroups":[{"filters":[{"value":"NPS","propertyName":"hs_survey_type","operator":"EQ"}]}],"properties":["hs_survey_type","hs_value"]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.FeedbackSubmission.Search(&hubspot.FeedbackSubmission{}, option)
+	if err != nil {
+		t.Fatalf("Search() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Search() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/feedback_submissions/search"; req.URL.Path != want {
+		t.Errorf("Search() request path mismatch: want %s got %s", want, req.URL.Path)
+	}
+	if string(req.Body) != wantBody {
+		t.Errorf("Search() request body mismatch: want %s got %s", wantBody, string(req.Body))
+	}
+}
@@ -0,0 +1,157 @@
+package hubspot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	fileBasePath = "files"
+)
+
+// Access levels of a file, the value of FileUploadOptions.Access and File.Access.
+const (
+	// FileAccessPublicIndexable is a public file that can be indexed by search engines.
+	FileAccessPublicIndexable = "PUBLIC_INDEXABLE"
+	// FileAccessPublicNotIndexable is a public file that is not indexed by search engines.
+	FileAccessPublicNotIndexable = "PUBLIC_NOT_INDEXABLE"
+	// FileAccessPrivate is a file that is only accessible with a signed URL.
+	FileAccessPrivate = "PRIVATE"
+)
+
+// FileService is an interface of file endpoints of the HubSpot API.
+// Uploaded files can be attached to records, e.g. as the attachment of a note.
+// Reference: https://developers.hubspot.com/docs/api/files/files
+type FileService interface {
+	Upload(upload *FileUpload) (*File, error)
+	Get(fileID string) (*File, error)
+	Delete(fileID string) error
+}
+
+// FileServiceOp handles communication with the file related methods of the HubSpot API.
+type FileServiceOp struct {
+	filePath string
+	client   *Client
+}
+
+var _ FileService = (*FileServiceOp)(nil)
+
+// File is a file stored in the file manager.
+type File struct {
+	ID             string  `json:"id,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Path           string  `json:"path,omitempty"`
+	Extension      string  `json:"extension,omitempty"`
+	Type           string  `json:"type,omitempty"`
+	Size           int64   `json:"size,omitempty"`
+	Access         string  `json:"access,omitempty"`
+	URL            string  `json:"url,omitempty"`
+	ParentFolderID string  `json:"parentFolderId,omitempty"`
+	Archived       bool    `json:"archived,omitempty"`
+	CreatedAt      *HsTime `json:"createdAt,omitempty"`
+	UpdatedAt      *HsTime `json:"updatedAt,omitempty"`
+}
+
+// FileUpload is a file to upload.
+// Name and Content are required, and either FolderPath or FolderID should be set.
+// Options.Access is required by HubSpot, use FileAccessPrivate for files that must not be public.
+type FileUpload struct {
+	Name       string
+	Content    io.Reader
+	FolderPath string
+	FolderID   string
+	Options    *FileUploadOptions
+}
+
+// FileUploadOptions is the options of a file upload, sent as the options JSON part.
+type FileUploadOptions struct {
+	Access    string `json:"access"`
+	Overwrite bool   `json:"overwrite,omitempty"`
+	// TTL is the time after which the file is deleted, e.g. "P3M". If empty, the file is kept.
+	TTL string `json:"ttl,omitempty"`
+	// DuplicateValidationStrategy is either "NONE", "REJECT" or "RETURN_EXISTING". Default is "NONE".
+	DuplicateValidationStrategy string `json:"duplicateValidationStrategy,omitempty"`
+	// DuplicateValidationScope is either "ENTIRE_PORTAL" or "EXACT_FOLDER".
+	DuplicateValidationScope string `json:"duplicateValidationScope,omitempty"`
+}
+
+// Upload uploads a file with a multipart form and returns it.
+// Use File.ID to attach the file to records, and File.URL to link to it.
+func (s *FileServiceOp) Upload(upload *FileUpload) (*File, error) {
+	if upload == nil || upload.Content == nil {
+		return nil, errors.New("the file content is not set")
+	}
+	body, contentType, err := upload.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &File{}
+	if err := s.client.createAndDoBody(context.Background(), http.MethodPost, s.filePath, body, contentType, nil, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// encode encodes the upload as a multipart form with the file, folder and options parts.
+// It returns the body and its content type with the boundary.
+func (u *FileUpload) encode() ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	part, err := w.CreateFormFile("file", u.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, u.Content); err != nil {
+		return nil, "", err
+	}
+
+	fields := []struct{ name, value string }{
+		{"fileName", u.Name},
+		{"folderPath", u.FolderPath},
+		{"folderId", u.FolderID},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if err := w.WriteField(f.name, f.value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if u.Options != nil {
+		options, err := json.Marshal(u.Options)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := w.WriteField("options", string(options)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// Get gets a file.
+func (s *FileServiceOp) Get(fileID string) (*File, error) {
+	resource := &File{}
+	if err := s.client.Get(s.filePath+"/"+fileID, resource, nil); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Delete deletes a file.
+func (s *FileServiceOp) Delete(fileID string) error {
+	return s.client.Delete(s.filePath + "/" + fileID)
+}
@@ -0,0 +1,100 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestFileServiceOp_Upload(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"file001","name":"contract","path":"/contracts/contract.pdf","extension":"pdf","type":"DOCUMENT","size":8,"access":"PRIVATE","url":"https://example.com/contracts/contract.pdf","parentFolderId":"folder001","createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}`),
+	}
+	upload := &hubspot.FileUpload{
+		Name:       "contract.pdf",
+		Content:    strings.NewReader("%PDF-1.4"),
+		FolderPath: "/contracts",
+		Options:    &hubspot.FileUploadOptions{Access: hubspot.FileAccessPrivate, Overwrite: true},
+	}
+	want := &hubspot.File{
+		ID:             "file001",
+		Name:           "contract",
+		Path:           "/contracts/contract.pdf",
+		Extension:      "pdf",
+		Type:           "DOCUMENT",
+		Size:           8,
+		Access:         hubspot.FileAccessPrivate,
+		URL:            "https://example.com/contracts/contract.pdf",
+		ParentFolderID: "folder001",
+		CreatedAt:      &createdAt,
+		UpdatedAt:      &updatedAt,
+	}
+	wantParts := map[string]string{
+		"file":       "%PDF-1.4",
+		"fileName":   "contract.pdf",
+		"folderPath": "/contracts",
+		"options":    `{"access":"PRIVATE","overwrite":true}`,
+	}
+
+	got, err := hubspot.NewMockClient(conf).Files.File.Upload(upload)
+	if err != nil {
+		t.Fatalf("Upload() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Upload() response mismatch (-want +got):%s", diff)
+	}
+
+	req := conf.Requests[0]
+	if want := "/files/v3/files"; req.Method != http.MethodPost || req.URL.Path != want {
+		t.Errorf("Upload() request mismatch: want POST %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Upload() content type mismatch: want multipart/form-data got %s", req.Header.Get("Content-Type"))
+	}
+	gotParts := map[string]string{}
+	r := multipart.NewReader(bytes.NewReader(req.Body), params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		b, _ := ioutil.ReadAll(part)
+		gotParts[part.FormName()] = string(b)
+		if part.FormName() == "file" && part.FileName() != "contract.pdf" {
+			t.Errorf("Upload() file name mismatch: want contract.pdf got %s", part.FileName())
+		}
+	}
+	if diff := cmp.Diff(wantParts, gotParts); diff != "" {
+		t.Errorf("Upload() request parts mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestFileServiceOp_Get(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"file001","name":"logo","extension":"png","access":"PUBLIC_NOT_INDEXABLE","url":"https://example.com/logo.png"}`),
+	}
+	want := &hubspot.File{ID: "file001", Name: "logo", Extension: "png", Access: hubspot.FileAccessPublicNotIndexable, URL: "https://example.com/logo.png"}
+
+	got, err := hubspot.NewMockClient(conf).Files.File.Get("file001")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/files/v3/files/file001"; conf.Requests[0].URL.Path != want {
+		t.Errorf("Get() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
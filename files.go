@@ -0,0 +1,21 @@
+package hubspot
+
+import "fmt"
+
+const (
+	filesBasePath = "files"
+)
+
+type Files struct {
+	File FileService
+}
+
+func newFiles(c *Client) *Files {
+	filesPath := fmt.Sprintf("%s/%s", filesBasePath, c.apiVersion)
+	return &Files{
+		File: &FileServiceOp{
+			filePath: fmt.Sprintf("%s/%s", filesPath, fileBasePath),
+			client:   c,
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	formBasePath = "forms"
+
+	formSubmitBasePath = "submissions/v3/integration/submit"
+)
+
+// hubSpotAPIDomain is the domain of the hosts of the HubSpot APIs other than the form submission API,
+// e.g. api.hubapi.com and api-eu1.hubapi.com.
+const hubSpotAPIDomain = "hubapi.com"
+
+// defaultFormSubmitBaseURL is the host of the form submission API, which differs from the other APIs.
+var defaultFormSubmitBaseURL = &url.URL{Scheme: "https", Host: "api.hsforms.com"}
+
+// FormService is an interface of form endpoints of the HubSpot API.
+// HubSpot forms capture information about visitors, and submitting a form creates or updates the contact.
+// Reference: https://developers.hubspot.com/docs/api/marketing/forms
+type FormService interface {
+	GetAll(option *RequestQueryOption) (*FormList, error)
+	Submit(portalID, formGUID string, submission *FormSubmission) (*FormSubmissionResponse, error)
+}
+
+// FormServiceOp handles communication with the form related methods of the HubSpot API.
+type FormServiceOp struct {
+	formPath string
+	client   *Client
+}
+
+var _ FormService = (*FormServiceOp)(nil)
+
+// Form is a HubSpot form.
+// The definition of the fields is kept as it is returned by HubSpot in FieldGroups.
+type Form struct {
+	ID          string        `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	FormType    string        `json:"formType,omitempty"`
+	FieldGroups []interface{} `json:"fieldGroups,omitempty"`
+	Archived    bool          `json:"archived,omitempty"`
+	CreatedAt   *HsTime       `json:"createdAt,omitempty"`
+	UpdatedAt   *HsTime       `json:"updatedAt,omitempty"`
+}
+
+// FormList is a page of forms.
+type FormList struct {
+	Results []*Form `json:"results"`
+	Paging  *Paging `json:"paging,omitempty"`
+}
+
+// FormSubmission is the data submitted to a form.
+type FormSubmission struct {
+	// SubmittedAt is the time of the submission in Unix milliseconds. If empty, the time HubSpot receives it is used.
+	SubmittedAt string       `json:"submittedAt,omitempty"`
+	Fields      []FormField  `json:"fields"`
+	Context     *FormContext `json:"context,omitempty"`
+	// LegalConsentOptions is the consent to process and communicate given by the visitor, sent as it is.
+	// Reference: https://legacydocs.hubspot.com/docs/methods/forms/submit_form_v3
+	LegalConsentOptions interface{} `json:"legalConsentOptions,omitempty"`
+}
+
+// FormField is the value of a field of a form.
+// ObjectTypeID is the object type of the property, e.g. "0-1" for contacts, and may be omitted for contact properties.
+type FormField struct {
+	ObjectTypeID string `json:"objectTypeId,omitempty"`
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+}
+
+// FormContext is the context of a form submission, used to attribute the submission to the visitor and the page.
+type FormContext struct {
+	// HUTK is the value of the hubspotutk cookie of the visitor.
+	HUTK      string `json:"hutk,omitempty"`
+	PageURI   string `json:"pageUri,omitempty"`
+	PageName  string `json:"pageName,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// FormSubmissionResponse is the response of a form submission.
+// Either the inline message or the redirect URI configured in the form is set.
+type FormSubmissionResponse struct {
+	InlineMessage string `json:"inlineMessage,omitempty"`
+	RedirectURI   string `json:"redirectUri,omitempty"`
+}
+
+// GetAll gets a page of forms.
+// Use RequestQueryOption.Limit and RequestQueryOption.After to get the following pages.
+func (s *FormServiceOp) GetAll(option *RequestQueryOption) (*FormList, error) {
+	resource := &FormList{}
+	if err := s.client.Get(s.formPath, resource, option); err != nil {
+		return nil, err
+	}
+	if resource.Results == nil {
+		resource.Results = []*Form{}
+	}
+	return resource, nil
+}
+
+// Submit submits the data to a form of the HubSpot account of portalID, e.g. to capture a lead server-side.
+// Set the hubspotutk cookie of the visitor in FormContext.HUTK to associate the submission with their activity.
+// The submission is sent to the form submission API, https://api.hsforms.com by default, or the URL set by
+// WithFormSubmitBaseURL, or the URL set by WithBaseURL if it is not a HubSpot host, e.g. a local server for testing.
+// The API takes no authentication, so the credentials of the client are not sent with the submission.
+func (s *FormServiceOp) Submit(portalID, formGUID string, submission *FormSubmission) (*FormSubmissionResponse, error) {
+	resource := &FormSubmissionResponse{}
+	rel := &url.URL{Path: fmt.Sprintf("%s/%s/%s", formSubmitBasePath, portalID, formGUID)}
+	path := s.client.formSubmitBaseURL().ResolveReference(rel).String()
+	ctx := withoutAuthentication(context.Background())
+	if err := s.client.CreateAndDoWithContext(ctx, http.MethodPost, path, submission, nil, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// formSubmitBaseURL returns the base URL of the form submission API: the URL set by WithFormSubmitBaseURL,
+// or the URL set by WithBaseURL if it is not a HubSpot host, e.g. a local test server, or defaultFormSubmitBaseURL.
+func (c *Client) formSubmitBaseURL() *url.URL {
+	if c.formSubmitURL != nil {
+		return c.formSubmitURL
+	}
+	if c.baseURL != nil && !isHubSpotAPIHost(c.baseURL.Hostname()) {
+		return c.baseURL
+	}
+	return defaultFormSubmitBaseURL
+}
@@ -0,0 +1,124 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestFormServiceOp_GetAll(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"id":"form001","name":"Contact us","formType":"hubspot","archived":false,"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}],"paging":{"next":{"after":"form002"}}}`),
+	}
+	want := &hubspot.FormList{
+		Results: []*hubspot.Form{
+			{ID: "form001", Name: "Contact us", FormType: "hubspot", CreatedAt: &createdAt, UpdatedAt: &updatedAt},
+		},
+		Paging: &hubspot.Paging{Next: &hubspot.PagingNext{After: "form002"}},
+	}
+
+	got, err := hubspot.NewMockClient(conf).Marketing.Form.GetAll(&hubspot.RequestQueryOption{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/marketing/v3/forms"; conf.Requests[0].URL.Path != want {
+		t.Errorf("GetAll() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestFormServiceOp_Submit(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"inlineMessage":"Thanks for submitting the form."}`),
+	}
+	submission := &hubspot.FormSubmission{
+		Fields: []hubspot.FormField{
+			{ObjectTypeID: "0-1", Name: "email", Value: "hubspot@example.com"},
+			{Name: "firstname", Value: "Jane"},
+		},
+		Context: &hubspot.FormContext{HUTK: "abc123", PageURI: "https://example.com/contact", PageName: "Contact us"},
+	}
+	want := &hubspot.FormSubmissionResponse{InlineMessage: "Thanks for submitting the form."}
+	wantBody := `{"fields":[{"objectTypeId":"0-1","name":"email","value":"hubspot@example.com"},{"name":"firstname","value":"Jane"}],"context":{"hutk":"abc123","pageUri":"https://example.com/contact","pageName":"Contact us"}}`
+
+	got, err := hubspot.NewMockClient(conf).Marketing.Form.Submit("123456", "form001", submission)
+	if err != nil {
+		t.Fatalf("Submit() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Submit() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "https://api.hsforms.com/submissions/v3/integration/submit/123456/form001"; req.Method != http.MethodPost || req.URL.String() != want {
+		t.Errorf("Submit() request mismatch: want POST %s got %s %s", want, req.Method, req.URL)
+	}
+	if string(req.Body) != wantBody {
+		t.Errorf("Submit() request body mismatch: want %s got %s", wantBody, string(req.Body))
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Submit() request header mismatch: want no Authorization got %s", got)
+	}
+}
+
+func TestFormServiceOp_Submit_BaseURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []hubspot.Option
+		want string
+	}{
+		{
+			name: "Default form submission API",
+			want: "https://api.hsforms.com/submissions/v3/integration/submit/123456/form001",
+		},
+		{
+			name: "Base URL of the client",
+			opts: []hubspot.Option{hubspot.WithBaseURL("http://localhost:8080/hubspot")},
+			want: "http://localhost:8080/hubspot/submissions/v3/integration/submit/123456/form001",
+		},
+		{
+			name: "Default form submission API with a HubSpot base URL",
+			opts: []hubspot.Option{hubspot.WithBaseURL("https://api-eu1.hubapi.com")},
+			want: "https://api.hsforms.com/submissions/v3/integration/submit/123456/form001",
+		},
+		{
+			name: "Form submission base URL",
+			opts: []hubspot.Option{
+				hubspot.WithBaseURL("https://api-eu1.hubapi.com"),
+				hubspot.WithFormSubmitBaseURL("https://forms.example.com"),
+			},
+			want: "https://forms.example.com/submissions/v3/integration/submit/123456/form001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: []byte(`{}`)}
+			opts := append([]hubspot.Option{hubspot.WithHTTPClient(hubspot.NewMockHTTPClient(conf))}, tt.opts...)
+			c, err := hubspot.NewClient(hubspot.SetAPIKey("key"), opts...)
+			if err != nil {
+				t.Fatalf("NewClient() unexpected error: %s", err)
+			}
+			if _, err := c.Marketing.Form.Submit("123456", "form001", &hubspot.FormSubmission{}); err != nil {
+				t.Fatalf("Submit() unexpected error: %s", err)
+			}
+			req := conf.Requests[0]
+			if got := req.URL.String(); got != tt.want {
+				t.Errorf("Submit() request url mismatch: want %s got %s", tt.want, got)
+			}
+			if got := req.Header.Get("Authorization"); got != "" {
+				t.Errorf("Submit() request header mismatch: want no Authorization got %s", got)
+			}
+		})
+	}
+
+	if err := hubspot.WithFormSubmitBaseURL("forms.example.com")(hubspot.NewMockClient(&hubspot.MockConfig{})); err == nil {
+		t.Error("WithFormSubmitBaseURL() error mismatch: want error for a relative url got nil")
+	}
+}
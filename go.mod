@@ -5,4 +5,6 @@ go 1.16
 require (
 	github.com/google/go-cmp v0.5.8
 	github.com/google/go-querystring v1.1.0
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
 )
@@ -2,24 +2,37 @@ package hubspot
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	defaultAPIVersion = "v3"
+
+	// modulePath is the path of this module, used to find its version in the build information.
+	modulePath = "bendingspoons.com/hubspot"
+	// userAgentProduct is the product of the default User-Agent, followed by the version of the module.
+	userAgentProduct = "teltech-go-hubspot"
 )
 
 var (
 	defaultBaseURL = &url.URL{Scheme: "https", Host: "api.hubapi.com"}
 
+	// defaultUserAgent is the User-Agent of the requests unless set by WithUserAgent, e.g. "teltech-go-hubspot/v1.2.0".
+	defaultUserAgent = userAgentProduct + "/" + moduleVersion()
+
 	// jsonPattern matches the JSON pattern.
 	// Used to extracts the error details contained in the error message.
 	jsonPattern = regexp.MustCompile(`{[\s\S]*?}`)
@@ -34,28 +47,194 @@ type Client struct {
 
 	authenticator Authenticator
 
-	CRM *CRM
+	// userAgent is the User-Agent header of the requests if set by WithUserAgent, defaultUserAgent otherwise.
+	userAgent string
+
+	// propertySchema validates the requested properties if set by WithPropertyValidation.
+	propertySchema *propertySchema
+
+	// timeout is the timeout of each request if set by WithTimeout.
+	timeout time.Duration
+	// metrics observes the requests if set by WithMetrics.
+	metrics Metrics
+	// tracer creates the spans of the requests if set by WithTracerProvider.
+	tracer trace.Tracer
+	// rateLimiter throttles the requests if set by WithRateLimit or WithRateLimitPerSecond.
+	rateLimiter *rateLimiter
+	// propertyTransform transforms the values of the properties if set by WithPropertyTransformer.
+	propertyTransform *propertyTransform
+	// archiveLimit is the number of objects a batch archive is allowed without confirmation if set by WithBatchArchiveLimit.
+	archiveLimit int
+	// formSubmitURL is the base URL of the form submission API if set by WithFormSubmitBaseURL.
+	formSubmitURL *url.URL
+	// writeSource is the prefix of the objectWriteTraceId of the batch write inputs if set by WithWriteSource.
+	writeSource string
+	// normalizeDomain normalizes the domain of the companies on Create and Update if set by WithDomainNormalization.
+	normalizeDomain bool
+	// dryRun stops the writes before they are sent if set by WithDryRun.
+	dryRun bool
+	// strictDecoding rejects the responses with unknown fields if set by WithStrictDecoding.
+	strictDecoding bool
+	// responseObserver receives the metadata of each response if set by WithResponseObserver.
+	responseObserver func(ctx context.Context, meta *ResponseMeta)
+
+	CRM       *CRM
+	Files     *Files
+	Marketing *Marketing
 }
 
 // RequestPayload is common request structure for HubSpot APIs.
+// Properties is a flat structure such as *Company, which is sent wrapped in the "properties" object HubSpot expects.
+// NOTE: HubSpot takes no source attribution on the single object writes, and the record source properties such as
+// hs_object_source are read-only. See ObjectSource and WithWriteSource.
 type RequestPayload struct {
-	Properties interface{} `json:"properties,omitempty"`
+	Properties   interface{}         `json:"properties,omitempty"`
+	Associations []CreateAssociation `json:"associations,omitempty"`
 }
 
 // ResponseResource is common response structure for HubSpot APIs.
+// The "properties" object of the response is unwrapped and bound to Properties, a flat structure such as *Company.
 type ResponseResource struct {
-	ID                 string              `json:"id,omitempty"`
-	Archived           bool                `json:"archived,omitempty"`
-	Associations       *Associations       `json:"associations,omitempty"`
-	Properties         interface{}         `json:"properties,omitempty"`
+	// ID is decoded from the object separately from the properties, so it is set whatever the structure bound to Properties.
+	ID string `json:"id,omitempty"`
+	// Archived and ArchivedAt are decoded from the object, alongside the properties, and tell the archived objects
+	// from the live ones, e.g. when listing with RequestQueryOption.Archived.
+	Archived              bool                         `json:"archived,omitempty"`
+	Associations          Associations                 `json:"associations,omitempty"`
+	Properties            interface{}                  `json:"properties,omitempty"`
+	PropertiesWithHistory map[string][]PropertyHistory `json:"propertiesWithHistory,omitempty"`
+	// CreatedAt and UpdatedAt are decoded from the object, alongside the properties, and are set even when
+	// the hs_createdate and hs_lastmodifieddate properties are not requested, including the results of search and batch read.
 	CreatedAt          *HsTime             `json:"createdAt,omitempty"`
 	UpdatedAt          *HsTime             `json:"updatedAt,omitempty"`
 	ArchivedAt         *HsTime             `json:"archivedAt,omitempty"`
 	AssociationResults []AssociationResult `json:"results,omitempty"`
+
+	// New is set by batch upsert, and reports whether the object was created rather than updated.
+	New bool `json:"new,omitempty"`
 }
 
 type ResponseResourceMulti struct {
+	// Total is the number of objects matching a search across all pages, and is not set by the other requests.
+	Total   int                `json:"total,omitempty"`
 	Results []ResponseResource `json:"results,omitempty"`
+	Paging  *Paging            `json:"paging,omitempty"`
+}
+
+// NextCursor returns the cursor of the next page and whether there is a next page.
+// Set the cursor to RequestQueryOption.After to get the next page.
+// If HubSpot only returns the link of the next page, the cursor is taken from its after query parameter,
+// as long as the link is relative or points at the host of baseURL, the base URL given to WithBaseURL.
+// If baseURL is empty, "https://api.hubapi.com" is used, as for a client without WithBaseURL.
+func (r *ResponseResourceMulti) NextCursor(baseURL string) (string, bool) {
+	if r == nil || r.Paging == nil || r.Paging.Next == nil {
+		return "", false
+	}
+	if r.Paging.Next.After != "" {
+		return r.Paging.Next.After, true
+	}
+	base := defaultBaseURL
+	if baseURL != "" {
+		u, err := parseBaseURL(baseURL)
+		if err != nil {
+			return "", false
+		}
+		base = u
+	}
+	link, err := resolvePagingLink(r.Paging, base)
+	if err != nil || link == nil {
+		return "", false
+	}
+	after := link.Query().Get("after")
+	return after, after != ""
+}
+
+// HasMore reports whether there is a next page, taking the cursor as NextCursor does with baseURL.
+func (r *ResponseResourceMulti) HasMore(baseURL string) bool {
+	_, ok := r.NextCursor(baseURL)
+	return ok
+}
+
+// Paging is the paging information of a list response.
+// If Next is nil, there are no more pages.
+type Paging struct {
+	Next *PagingNext `json:"next,omitempty"`
+}
+
+// isHubSpotAPIHost reports whether the host is one of the HubSpot APIs other than the form submission API.
+func isHubSpotAPIHost(host string) bool {
+	return host == hubSpotAPIDomain || strings.HasSuffix(host, "."+hubSpotAPIDomain)
+}
+
+// pagingLink returns the link of the next page when HubSpot returned it without a cursor, or nil.
+// An error wrapping ErrPagingLinkHost is returned if the link points at another host than the base URL of the client.
+func (c *Client) pagingLink(p *Paging) (*url.URL, error) {
+	return resolvePagingLink(p, c.baseURL)
+}
+
+// resolvePagingLink is pagingLink with the given base URL, so that ResponseResourceMulti.NextCursor accepts the same links as the client.
+func resolvePagingLink(p *Paging, baseURL *url.URL) (*url.URL, error) {
+	if p == nil || p.Next == nil || p.Next.After != "" || p.Next.Link == "" {
+		return nil, nil
+	}
+	link, err := url.Parse(p.Next.Link)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the paging link: %w", err)
+	}
+	if link.Host != "" && !strings.EqualFold(link.Host, baseURL.Host) {
+		return nil, fmt.Errorf("%w: %s", ErrPagingLinkHost, link.Host)
+	}
+	return baseURL.ResolveReference(link), nil
+}
+
+// nextCursor returns the cursor of the next page and whether there is a next page.
+// Some endpoints only return the link of the next page, whose after query parameter is then the cursor,
+// and the link must point at the host of the base URL of the client.
+func (c *Client) nextCursor(p *Paging) (string, bool, error) {
+	if p == nil || p.Next == nil {
+		return "", false, nil
+	}
+	if p.Next.After != "" {
+		return p.Next.After, true, nil
+	}
+	link, err := c.pagingLink(p)
+	if err != nil || link == nil {
+		return "", false, err
+	}
+	after := link.Query().Get("after")
+	return after, after != "", nil
+}
+
+// nextPage returns the path and the option of the request of the next page of a GET request at path with option,
+// and whether there is a next page. The cursor is set to the After of a copy of the option.
+// A link without an after query parameter is requested as it is, since its query already selects the next page,
+// and only when it points at the host of the base URL of the client.
+func (c *Client) nextPage(p *Paging, path string, option *RequestQueryOption) (string, *RequestQueryOption, bool, error) {
+	after, ok, err := c.nextCursor(p)
+	if err != nil {
+		return "", nil, false, err
+	}
+	if ok {
+		next := &RequestQueryOption{}
+		if option != nil {
+			*next = *option
+		}
+		next.After = after
+		return path, next, true, nil
+	}
+	link, err := c.pagingLink(p)
+	if err != nil || link == nil {
+		return "", nil, false, err
+	}
+	return link.String(), nil, true, nil
+}
+
+// PagingNext is the cursor of the next page.
+// Set After to RequestQueryOption.After to get the next page,
+// or use ResponseResourceMulti.NextCursor, which also takes the cursor from Link when only Link is set.
+type PagingNext struct {
+	After string `json:"after,omitempty"`
+	Link  string `json:"link,omitempty"`
 }
 
 // check if needed for get all in owners
@@ -79,16 +258,21 @@ func NewClient(setAuthMethod AuthMethod, opts ...Option) (*Client, error) {
 		apiVersion: defaultAPIVersion,
 	}
 
+	for _, o := range opts {
+		if err := o(c); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set the authentication method specified by the argument.
 	// Authentication method is either APIKey or OAuth.
+	// Since the authentication method depends on the baseURL and HTTPClient, set it after applying the options.
 	setAuthMethod(c)
 
-	for _, o := range opts {
-		o(c)
-	}
-
 	// Since the baseURL and apiVersion may change, initialize the service after applying the options.
 	c.CRM = newCRM(c)
+	c.Files = newFiles(c)
+	c.Marketing = newMarketing(c)
 
 	return c, nil
 }
@@ -96,6 +280,12 @@ func NewClient(setAuthMethod AuthMethod, opts ...Option) (*Client, error) {
 // NewRequest creates an API request.
 // After creating a request, add the authentication information according to the method specified in NewClient().
 func (c *Client) NewRequest(method, path string, body, option interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, body, option)
+}
+
+// NewRequestWithContext creates an API request with the given context.
+// The context controls the entire lifetime of the request and its response.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body, option interface{}) (*http.Request, error) {
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -108,8 +298,17 @@ func (c *Client) NewRequest(method, path string, body, option interface{}) (*htt
 		if err != nil {
 			return nil, err
 		}
+		if c.propertyTransform != nil {
+			if js, err = c.propertyTransform.encode(js); err != nil {
+				return nil, err
+			}
+		}
 	}
+	return c.newRequest(ctx, method, rel, js, "application/json", option)
+}
 
+// newRequest creates an API request with the body already encoded in the content type.
+func (c *Client) newRequest(ctx context.Context, method string, rel *url.URL, body []byte, contentType string, option interface{}) (*http.Request, error) {
 	// Make the full url based on the relative path
 	u := c.baseURL.ResolveReference(rel)
 
@@ -127,16 +326,26 @@ func (c *Client) NewRequest(method, path string, body, option interface{}) (*htt
 		u.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	// The body is read from a bytes.Reader, for which http.NewRequestWithContext sets GetBody to replay it
+	// when the request is sent again, e.g. on a retry or a 307/308 redirect.
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
+	userAgent := defaultUserAgent
+	if c.userAgent != "" {
+		userAgent = c.userAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
 
 	// Configure authentication settings using the method specified during NewClient().
-	if err := c.authenticator.SetAuthentication(req); err != nil {
-		return nil, err
+	// The requests to the APIs taking no authentication are sent without the credentials.
+	if !isUnauthenticated(ctx) {
+		if err := c.authenticator.SetAuthentication(req); err != nil {
+			return nil, err
+		}
 	}
 
 	return req, nil
@@ -149,16 +358,60 @@ func (c *Client) NewRequest(method, path string, body, option interface{}) (*htt
 // The resource argument is marshalled data returned from HubSpot.
 // If the resource contains a pointer to data, the data will be overwritten with the content of the response.
 func (c *Client) CreateAndDo(method, relPath string, data, option, resource interface{}) error {
+	return c.CreateAndDoWithContext(context.Background(), method, relPath, data, option, resource)
+}
+
+// CreateAndDoWithContext performs a web request to HubSpot with the given context.
+// The arguments other than the context are the same as CreateAndDo.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, relPath string, data, option, resource interface{}) error {
 	if strings.HasPrefix(relPath, "/") {
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
-	req, err := c.NewRequest(method, relPath, data, option)
+	if c.propertySchema != nil {
+		if err := c.propertySchema.validate(c, relPath, option, data); err != nil {
+			return err
+		}
+	}
+
+	req, err := c.NewRequestWithContext(ctx, method, relPath, data, option)
 	if err != nil {
 		return err
 	}
+	return c.do(relPath, req, data, resource)
+}
+
+// createAndDoBody performs a web request to HubSpot with a body already encoded in the content type, such as a
+// multipart form, through the same validation as CreateAndDoWithContext.
+func (c *Client) createAndDoBody(ctx context.Context, method, relPath string, body []byte, contentType string, option, resource interface{}) error {
+	relPath = strings.TrimLeft(relPath, "/")
 
-	_, err = c.doGetHeaders(req, resource)
+	if c.propertySchema != nil {
+		if err := c.propertySchema.validate(c, relPath, option, nil); err != nil {
+			return err
+		}
+	}
+
+	rel, err := url.Parse(relPath)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, method, rel, body, contentType, option)
+	if err != nil {
+		return err
+	}
+	return c.do(relPath, req, nil, resource)
+}
+
+// do validates the payload of a write request in dry run and sends the request, decoding the response into resource.
+func (c *Client) do(relPath string, req *http.Request, data, resource interface{}) error {
+	if c.dryRun && c.propertySchema != nil && isWriteRequest(req) {
+		if err := c.propertySchema.validatePayload(c, relPath, data); err != nil {
+			return err
+		}
+	}
+
+	_, err := c.doGetHeaders(req, resource)
 	if err != nil {
 		return err
 	}
@@ -168,19 +421,78 @@ func (c *Client) CreateAndDo(method, relPath string, data, option, resource inte
 
 // doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
 // FIXME: Add optional retry process
-func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
-	resp, err := c.HTTPClient.Do(req)
+func (c *Client) doGetHeaders(req *http.Request, v interface{}) (header http.Header, err error) {
+	if c.timeout > 0 {
+		// The timeout also covers decoding the response body, so cancel it only after returning.
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	if c.dryRun && isWriteRequest(req) {
+		return nil, newDryRunError(req)
+	}
+
+	if c.rateLimiter != nil {
+		// Waiting for a token is bounded by the context, including the timeout.
+		if err := c.rateLimiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *http.Response
+	if c.tracer != nil {
+		var span trace.Span
+		req, span = c.startSpan(req)
+		defer func() { endSpan(span, resp, err) }()
+	}
+
+	var start time.Time
+	if c.metrics != nil {
+		start = time.Now()
+	}
+
+	resp, err = c.HTTPClient.Do(req)
+	if c.metrics != nil {
+		// The latency also covers reading the response body, so observe it only after returning.
+		defer c.observeRequest(req, resp, start)
+	}
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if c.rateLimiter != nil {
+		c.rateLimiter.observe(resp.Header)
+	}
+
+	if c.responseObserver != nil {
+		// It is also called for error responses, whose request ID is the most useful for HubSpot support.
+		c.observeResponse(req, resp)
+	}
+
 	if resErr := CheckResponseError(resp); resErr != nil {
 		return nil, resErr
 	}
 
 	if v != nil {
-		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		var body io.Reader = resp.Body
+		if c.propertyTransform != nil {
+			if body, err = c.propertyTransform.decode(resp.Body); err != nil {
+				return nil, err
+			}
+		}
+		if c.strictDecoding {
+			data, err := io.ReadAll(body)
+			if err != nil {
+				return nil, err
+			}
+			if err := c.unmarshal(data, v); err != nil {
+				return nil, err
+			}
+			return resp.Header, nil
+		}
+		if err := json.NewDecoder(body).Decode(v); err != nil {
 			return nil, err
 		}
 	}
@@ -196,12 +508,28 @@ func CheckResponseError(r *http.Response) error {
 	hubspotErr := &APIError{
 		HTTPStatusCode: r.StatusCode,
 	}
+	var retryAfter time.Duration
+	var hasRetryAfter bool
+	var policyName string
+	if r.StatusCode == http.StatusTooManyRequests {
+		retryAfter, hasRetryAfter = parseRetryAfter(r.Header.Get("Retry-After"), time.Now())
+		hubspotErr.RetryAfter = retryAfter
+		hubspotErr.HasRetryAfter = hasRetryAfter
+		// The body may still have another policy, in which case it is kept.
+		if r.Header.Get(rateLimitDailyRemainingHeader) == "0" {
+			policyName = RateLimitPolicyDaily
+			hubspotErr.PolicyName = policyName
+		}
+	}
 
 	if r.Body != nil {
 		if err := json.NewDecoder(r.Body).Decode(hubspotErr); err != nil {
 			return &APIError{
 				HTTPStatusCode: r.StatusCode,
 				Message:        fmt.Sprintf("unable to read response from hubspot: %s", err),
+				RetryAfter:     retryAfter,
+				HasRetryAfter:  hasRetryAfter,
+				PolicyName:     policyName,
 			}
 		}
 		// HubSpot contain error details in the error message, so we need to extract them with a regexp.
@@ -219,6 +547,9 @@ func CheckResponseError(r *http.Response) error {
 				hubspotErr.Details = append(hubspotErr.Details, errDetail)
 			}
 		}
+		if r.StatusCode == http.StatusConflict {
+			hubspotErr.ExistingObjectID = extractExistingObjectID(hubspotErr.Message)
+		}
 	}
 
 	return hubspotErr
@@ -234,6 +565,34 @@ func (c *Client) Get(path string, resource interface{}, option *RequestQueryOpti
 	return c.CreateAndDo(http.MethodGet, path, nil, option, resource)
 }
 
+// Do performs a request to any endpoint of HubSpot, including those without a service in this package.
+// The path is relative to the base URL and may have a query, e.g. "analytics/v2/reports/totals/total?start=20220101".
+// The body is sent as JSON, and the response is decoded into out, which may be nil to discard it.
+// The request is authenticated and the errors are returned as *APIError in the same way as the services.
+func (c *Client) Do(method, path string, body, out interface{}) error {
+	return c.CreateAndDo(method, path, body, nil, out)
+}
+
+// Ping verifies the credentials with a cheap read-only request, getting a single owner.
+// It is intended as a readiness check, and the errors are distinguished as follows:
+//   - errors.Is(err, ErrUnauthorized): the API key or access token is invalid.
+//   - errors.Is(err, ErrForbidden): the credentials lack the crm.objects.owners.read scope.
+//   - errors.Is(err, ErrUnreachable): HubSpot cannot be reached.
+//
+// The error of the context is returned as it is when the context is done.
+func (c *Client) Ping(ctx context.Context) error {
+	path := fmt.Sprintf("%s/%s/%s", crmBasePath, c.apiVersion, ownerBasePath)
+	err := c.CreateAndDoWithContext(ctx, http.MethodGet, path, nil, &RequestQueryOption{Limit: 1}, nil)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("%w: %s", ErrUnreachable, err)
+	}
+	return err
+}
+
 // Post performs a POST request for the given path and saves the result in the given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
 	return c.CreateAndDo(http.MethodPost, path, data, nil, resource)
@@ -253,3 +612,18 @@ func (c *Client) Patch(path string, data, resource interface{}) error {
 func (c *Client) Delete(path string) error {
 	return c.CreateAndDo(http.MethodDelete, path, nil, nil, nil)
 }
+
+// moduleVersion returns the version of this module in the build, or "devel" if it is not known,
+// e.g. when the module is built as the main module or replaced by a local directory.
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath && dep.Replace == nil && dep.Version != "" {
+			return dep.Version
+		}
+	}
+	return "devel"
+}
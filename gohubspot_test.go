@@ -2,7 +2,9 @@ package hubspot_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -95,7 +97,7 @@ func TestNewClient(t *testing.T) {
 			name: "Success new client with custom base url",
 			args: args{
 				setAuthMethod: hubspot.SetAPIKey("key"),
-				opts:          []hubspot.Option{hubspot.WithBaseURL(&url.URL{Scheme: "http", Host: "example.com"})},
+				opts:          []hubspot.Option{hubspot.WithBaseURL("http://example.com")},
 			},
 			settings: settings{
 				client:     http.DefaultClient,
@@ -119,6 +121,29 @@ func TestNewClient(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "Success new client with EU base url",
+			args: args{
+				setAuthMethod: hubspot.SetAPIKey("key"),
+				opts:          []hubspot.Option{hubspot.WithBaseURL("https://api-eu1.hubapi.com")},
+			},
+			settings: settings{
+				client:     http.DefaultClient,
+				baseURL:    &url.URL{Scheme: "https", Host: "api-eu1.hubapi.com"},
+				apiVersion: hubspot.ExportAPIVersion,
+				authMethod: hubspot.SetAPIKey("key"),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Failed new client because base url is not absolute",
+			args: args{
+				setAuthMethod: hubspot.SetAPIKey("key"),
+				opts:          []hubspot.Option{hubspot.WithBaseURL("api.hubapi.com")},
+			},
+			settings: settings{},
+			wantErr:  errors.New(`invalid base url: "api.hubapi.com" is not an absolute url`),
+		},
 		{
 			name: "Failed new client because not set auth method",
 			args: args{
@@ -138,6 +163,8 @@ func TestNewClient(t *testing.T) {
 				want.ExportSetAPIVersion(tt.settings.apiVersion)
 				want.ExportSetBaseURL(tt.settings.baseURL)
 				want.CRM = hubspot.ExportNewCRM(want)
+				want.Files = hubspot.ExportNewFiles(want)
+				want.Marketing = hubspot.ExportNewMarketing(want)
 				tt.settings.authMethod(want)
 			}
 
@@ -208,6 +235,7 @@ func TestClient_NewRequest(t *testing.T) {
 				body:   nil,
 				header: http.Header{
 					"Content-Type": []string{"application/json"},
+					"User-Agent":   []string{"teltech-go-hubspot/devel"},
 				},
 			},
 			wantErr: nil,
@@ -236,6 +264,7 @@ func TestClient_NewRequest(t *testing.T) {
 				body:   []byte(`{"id":"001","name":"example"}`),
 				header: http.Header{
 					"Content-Type": []string{"application/json"},
+					"User-Agent":   []string{"teltech-go-hubspot/devel"},
 				},
 			},
 			wantErr: nil,
@@ -273,6 +302,7 @@ func TestClient_NewRequest(t *testing.T) {
 				header: http.Header{
 					"Content-Type":  []string{"application/json"},
 					"Authorization": []string{"Bearer test_access_token"},
+					"User-Agent":    []string{"teltech-go-hubspot/devel"},
 				},
 			},
 			wantErr: nil,
@@ -367,7 +397,7 @@ func TestClient_CreateAndDo(t *testing.T) {
 				ID:       "512",
 				Archived: false,
 				Properties: &hubspot.Deal{
-					Amount:           hubspot.NewString("1500.00"),
+					Amount:           hubspot.NewFloat(1500),
 					DealName:         hubspot.NewString("Custom data integrations"),
 					DealStage:        hubspot.NewString("presentation scheduled"),
 					DealOwnerID:      hubspot.NewString("910901"),
@@ -398,7 +428,7 @@ func TestClient_CreateAndDo(t *testing.T) {
 				method:  http.MethodGet,
 				relPath: "crm/v3/objects/deals",
 				data: &hubspot.Deal{
-					Amount:      hubspot.NewString("1500.00"),
+					Amount:      hubspot.NewFloat(1500),
 					DealName:    hubspot.NewString("Custom data integrations"),
 					DealStage:   hubspot.NewString("presentation scheduled"),
 					DealOwnerID: hubspot.NewString("910901"),
@@ -411,7 +441,7 @@ func TestClient_CreateAndDo(t *testing.T) {
 				ID:       "512",
 				Archived: false,
 				Properties: &hubspot.Deal{
-					Amount:           hubspot.NewString("1500.00"),
+					Amount:           hubspot.NewFloat(1500),
 					DealName:         hubspot.NewString("Custom data integrations"),
 					DealStage:        hubspot.NewString("presentation scheduled"),
 					DealOwnerID:      hubspot.NewString("910901"),
@@ -537,6 +567,23 @@ func TestCheckResponseError(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Response Conflict with existing object id",
+			args: args{
+				r: &http.Response{
+					StatusCode: http.StatusConflict,
+					Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(`{"status":"error","message":"Contact already exists. Existing ID: 512","correlationId":"aeb5f871-7f07-4993-9211-075dc63e7cbf","category":"CONFLICT"}`))),
+				},
+			},
+			wantErr: &hubspot.APIError{
+				HTTPStatusCode:   http.StatusConflict,
+				Message:          "Contact already exists. Existing ID: 512",
+				CorrelationID:    "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				Category:         "CONFLICT",
+				Status:           "error",
+				ExistingObjectID: "512",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -547,3 +594,314 @@ func TestCheckResponseError(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "Conflict matches ErrConflict",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusConflict},
+			target: hubspot.ErrConflict,
+			want:   true,
+		},
+		{
+			name:   "Wrapped conflict matches ErrConflict",
+			err:    fmt.Errorf("create company: %w", &hubspot.APIError{HTTPStatusCode: http.StatusConflict}),
+			target: hubspot.ErrConflict,
+			want:   true,
+		},
+		{
+			name:   "Bad request does not match ErrConflict",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusBadRequest},
+			target: hubspot.ErrConflict,
+			want:   false,
+		},
+		{
+			name:   "Not found matches ErrNotFound",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusNotFound},
+			target: hubspot.ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "Gone matches ErrGone",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusGone},
+			target: hubspot.ErrGone,
+			want:   true,
+		},
+		{
+			name:   "Too many requests matches ErrRateLimited",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests},
+			target: hubspot.ErrRateLimited,
+			want:   true,
+		},
+		{
+			name:   "Daily limit matches ErrDailyLimitExceeded",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, PolicyName: hubspot.RateLimitPolicyDaily},
+			target: hubspot.ErrDailyLimitExceeded,
+			want:   true,
+		},
+		{
+			name:   "Daily limit matches ErrRateLimited",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, PolicyName: hubspot.RateLimitPolicyDaily},
+			target: hubspot.ErrRateLimited,
+			want:   true,
+		},
+		{
+			name:   "Secondly limit does not match ErrDailyLimitExceeded",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests, PolicyName: hubspot.RateLimitPolicySecondly},
+			target: hubspot.ErrDailyLimitExceeded,
+			want:   false,
+		},
+		{
+			name:   "Unauthorized matches ErrUnauthorized",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusUnauthorized},
+			target: hubspot.ErrUnauthorized,
+			want:   true,
+		},
+		{
+			name:   "Forbidden matches ErrForbidden",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusForbidden},
+			target: hubspot.ErrForbidden,
+			want:   true,
+		},
+		{
+			name:   "Forbidden does not match ErrUnauthorized",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusForbidden},
+			target: hubspot.ErrUnauthorized,
+			want:   false,
+		},
+		{
+			name:   "Not found does not match ErrGone",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusNotFound},
+			target: hubspot.ErrGone,
+			want:   false,
+		},
+		{
+			name:   "Not found does not match ErrConflict",
+			err:    &hubspot.APIError{HTTPStatusCode: http.StatusNotFound},
+			target: hubspot.ErrConflict,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() mismatch: want %v got %v", tt.want, got)
+			}
+			var apiErr *hubspot.APIError
+			if !errors.As(tt.err, &apiErr) {
+				t.Errorf("errors.As() mismatch: want *APIError got %T", tt.err)
+			}
+		})
+	}
+}
+
+func TestResponseResourceMulti_NextCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		resource   *hubspot.ResponseResourceMulti
+		baseURL    string
+		wantCursor string
+		wantOK     bool
+	}{
+		{
+			name:       "Has a next page",
+			resource:   &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{After: "page2", Link: "?after=page2"}}},
+			wantCursor: "page2",
+			wantOK:     true,
+		},
+		{
+			name:       "Only the link of the next page",
+			resource:   &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{Link: "https://api.hubapi.com/crm/v3/objects/companies?limit=10&after=page2"}}},
+			wantCursor: "page2",
+			wantOK:     true,
+		},
+		{
+			name:     "Link to another host",
+			resource: &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{Link: "https://example.com/crm/v3/objects/companies?limit=10&after=page2"}}},
+		},
+		{
+			name:       "Link to the custom base URL",
+			resource:   &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{Link: "http://localhost:8080/crm/v3/objects/companies?limit=10&after=page2"}}},
+			baseURL:    "http://localhost:8080",
+			wantCursor: "page2",
+			wantOK:     true,
+		},
+		{
+			name:     "Link to the HubSpot API with a custom base URL",
+			resource: &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{Link: "https://api.hubapi.com/crm/v3/objects/companies?limit=10&after=page2"}}},
+			baseURL:  "http://localhost:8080",
+		},
+		{
+			name:     "Link to another HubSpot API host",
+			resource: &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{Link: "https://api-eu1.hubapi.com/crm/v3/objects/companies?limit=10&after=page2"}}},
+		},
+		{
+			name:     "Link without cursor",
+			resource: &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{Link: "https://api.hubapi.com/crm/v3/objects/companies?limit=10"}}},
+		},
+		{
+			name:     "No paging",
+			resource: &hubspot.ResponseResourceMulti{},
+		},
+		{
+			name:     "No next page",
+			resource: &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{}},
+		},
+		{
+			name:     "Empty cursor",
+			resource: &hubspot.ResponseResourceMulti{Paging: &hubspot.Paging{Next: &hubspot.PagingNext{}}},
+		},
+		{
+			name: "Nil response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, ok := tt.resource.NextCursor(tt.baseURL)
+			if cursor != tt.wantCursor || ok != tt.wantOK {
+				t.Errorf("NextCursor() mismatch: want (%q, %v) got (%q, %v)", tt.wantCursor, tt.wantOK, cursor, ok)
+			}
+			if got := tt.resource.HasMore(tt.baseURL); got != tt.wantOK {
+				t.Errorf("HasMore() mismatch: want %v got %v", tt.wantOK, got)
+			}
+		})
+	}
+}
+
+func TestClient_NewRequest_GetBody(t *testing.T) {
+	var bodies []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			// Redirect the first attempt with 307, which makes the http.Client replay the body with GetBody.
+			if len(bodies) == 1 {
+				return &http.Response{
+					StatusCode: http.StatusTemporaryRedirect,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					Header:     http.Header{"Location": []string{"https://api.hubapi.com/crm/v3/objects/companies/search?redirected=true"}},
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"results":[]}`)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	option := (&hubspot.RequestSearchOption{Properties: []string{"name"}}).WithQuery("acme")
+
+	if _, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.Company.Search(&hubspot.Company{}, option); err != nil {
+		t.Fatalf("Search() unexpected error: %s", err)
+	}
+	want := `{"query":"acme","properties":["name"]}`
+	if diff := cmp.Diff([]string{want, want}, bodies); diff != "" {
+		t.Errorf("request body mismatch between attempts (-want +got):%s", diff)
+	}
+
+	req, err := hubspot.NewMockClient(&hubspot.MockConfig{}).NewRequest(http.MethodPost, "crm/v3/objects/companies", option, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		body, err := req.GetBody()
+		if err != nil {
+			t.Fatalf("GetBody() unexpected error: %s", err)
+		}
+		got, _ := ioutil.ReadAll(body)
+		if string(got) != want {
+			t.Errorf("GetBody() mismatch: want %s got %s", want, string(got))
+		}
+	}
+}
+
+func TestClient_Do(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"totals":{"visits":42}}`),
+	}
+	var got struct {
+		Totals struct {
+			Visits int `json:"visits"`
+		} `json:"totals"`
+	}
+
+	if err := hubspot.NewMockClient(conf).Do(http.MethodGet, "analytics/v2/reports/totals/total?start=20220101", nil, &got); err != nil {
+		t.Fatalf("Do() unexpected error: %s", err)
+	}
+	if got.Totals.Visits != 42 {
+		t.Errorf("Do() response mismatch: want 42 visits got %d", got.Totals.Visits)
+	}
+	req := conf.Requests[0]
+	if want := "/analytics/v2/reports/totals/total"; req.Method != http.MethodGet || req.URL.Path != want || req.URL.Query().Get("start") != "20220101" {
+		t.Errorf("Do() request mismatch: want GET %s?start=20220101 got %s %s", want, req.Method, req.URL)
+	}
+
+	notFound := &hubspot.MockConfig{
+		Status: http.StatusNotFound,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"error","message":"Not found","category":"OBJECT_NOT_FOUND"}`),
+	}
+	if err := hubspot.NewMockClient(notFound).Do(http.MethodPost, "analytics/v2/reports", map[string]string{"name": "report"}, nil); !errors.Is(err, hubspot.ErrNotFound) {
+		t.Errorf("Do() error mismatch: want ErrNotFound got %v", err)
+	}
+	if want := `{"name":"report"}`; string(notFound.Requests[0].Body) != want {
+		t.Errorf("Do() request body mismatch: want %s got %s", want, string(notFound.Requests[0].Body))
+	}
+}
+
+type failingRoundTripper struct {
+	err error
+}
+
+func (rt *failingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, rt.err
+}
+
+func TestClient_Ping(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpClient *http.Client
+		wantErr    error
+	}{
+		{
+			name:       "Success",
+			httpClient: hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: []byte(`{"results":[]}`)}),
+		},
+		{
+			name:       "Invalid credentials",
+			httpClient: hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusUnauthorized, Header: http.Header{}, Body: []byte(`{"status":"error","category":"INVALID_AUTHENTICATION"}`)}),
+			wantErr:    hubspot.ErrUnauthorized,
+		},
+		{
+			name:       "Missing scopes",
+			httpClient: hubspot.NewMockHTTPClient(&hubspot.MockConfig{Status: http.StatusForbidden, Header: http.Header{}, Body: []byte(`{"status":"error","category":"MISSING_SCOPES"}`)}),
+			wantErr:    hubspot.ErrForbidden,
+		},
+		{
+			name:       "Connection failure",
+			httpClient: &http.Client{Transport: &failingRoundTripper{err: errors.New("connection refused")}},
+			wantErr:    hubspot.ErrUnreachable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hubspot.NewMockClientWithHTTPClient(tt.httpClient).Ping(context.Background())
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Ping() unexpected error: %s", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ping() error mismatch: want %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
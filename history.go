@@ -0,0 +1,58 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+)
+
+// maxPropertiesWithHistory is the maximum number of properties whose history HubSpot returns in a request.
+// Requesting more is rejected with 400 Bad Request.
+const maxPropertiesWithHistory = 10
+
+// PropertyHistory is a value a property had, set in ResponseResource.PropertiesWithHistory.
+// The source fields are empty when HubSpot returns null for them.
+type PropertyHistory struct {
+	Value           string  `json:"value"`
+	Timestamp       *HsTime `json:"timestamp,omitempty"`
+	SourceType      string  `json:"sourceType,omitempty"`
+	SourceID        string  `json:"sourceId,omitempty"`
+	SourceLabel     string  `json:"sourceLabel,omitempty"`
+	UpdatedByUserID int     `json:"updatedByUserId,omitempty"`
+}
+
+// getWithHistory gets an object, splitting RequestQueryOption.PropertiesWithHistory into several requests
+// when more than maxPropertiesWithHistory properties are requested, and merging the history into the resource.
+func getWithHistory(ctx context.Context, c *Client, path string, resource *ResponseResource, option *RequestQueryOption) error {
+	history := option.PropertiesWithHistory
+	if len(history) <= maxPropertiesWithHistory {
+		return c.CreateAndDoWithContext(ctx, http.MethodGet, path, nil, option, resource)
+	}
+
+	first := *option
+	first.PropertiesWithHistory = history[:maxPropertiesWithHistory]
+	if err := c.CreateAndDoWithContext(ctx, http.MethodGet, path, nil, &first, resource); err != nil {
+		return err
+	}
+	if resource.PropertiesWithHistory == nil {
+		resource.PropertiesWithHistory = map[string][]PropertyHistory{}
+	}
+
+	for start := maxPropertiesWithHistory; start < len(history); start += maxPropertiesWithHistory {
+		end := start + maxPropertiesWithHistory
+		if end > len(history) {
+			end = len(history)
+		}
+		// The properties were got by the first request, so only the history is requested.
+		rest := *option
+		rest.Properties = []string{"hs_object_id"}
+		rest.PropertiesWithHistory = history[start:end]
+		chunk := &ResponseResource{}
+		if err := c.CreateAndDoWithContext(ctx, http.MethodGet, path, nil, &rest, chunk); err != nil {
+			return err
+		}
+		for name, values := range chunk.PropertiesWithHistory {
+			resource.PropertiesWithHistory[name] = values
+		}
+	}
+	return nil
+}
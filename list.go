@@ -0,0 +1,176 @@
+package hubspot
+
+const (
+	listBasePath = "lists"
+
+	// listMembersPageLimit is the maximum number of members HubSpot returns in a page.
+	listMembersPageLimit = 250
+	// listSearchPageLimit is the maximum number of lists HubSpot returns in a page of list search.
+	listSearchPageLimit = 500
+)
+
+// Object type IDs of the records of a list, the value of List.ObjectTypeID.
+const (
+	ListObjectTypeIDContact = "0-1"
+	ListObjectTypeIDCompany = "0-2"
+)
+
+// ListProcessingType is the value of List.ProcessingType.
+const (
+	// ListProcessingTypeManual is a static list whose members are only added and removed manually.
+	ListProcessingTypeManual = "MANUAL"
+	// ListProcessingTypeSnapshot is a static list populated once from its filters, whose members can then be managed manually.
+	ListProcessingTypeSnapshot = "SNAPSHOT"
+	// ListProcessingTypeDynamic is an active list whose members are updated by HubSpot from its filters.
+	// The members of a dynamic list are read-only.
+	ListProcessingTypeDynamic = "DYNAMIC"
+)
+
+// ListService is an interface of list endpoints of the HubSpot API.
+// Lists segment records such as contacts or companies.
+// The members of MANUAL and SNAPSHOT lists can be added and removed, while the members of DYNAMIC lists are read-only.
+// Reference: https://developers.hubspot.com/docs/api/crm/lists
+type ListService interface {
+	Create(list *List) (*List, error)
+	Get(listID string) (*List, error)
+	GetAll() ([]*List, error)
+	Delete(listID string) error
+	AddMembers(listID string, ids []string) error
+	RemoveMembers(listID string, ids []string) error
+	Members(listID string) (*ResponseResourceMulti, error)
+}
+
+// ListServiceOp handles communication with the list related methods of the HubSpot API.
+type ListServiceOp struct {
+	listPath string
+	client   *Client
+}
+
+var _ ListService = (*ListServiceOp)(nil)
+
+type List struct {
+	ListID           string  `json:"listId,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	ObjectTypeID     string  `json:"objectTypeId,omitempty"`
+	ProcessingType   string  `json:"processingType,omitempty"`
+	ProcessingStatus string  `json:"processingStatus,omitempty"`
+	Size             int     `json:"size,omitempty"`
+	CreatedAt        *HsTime `json:"createdAt,omitempty"`
+	UpdatedAt        *HsTime `json:"updatedAt,omitempty"`
+
+	// FilterBranch is the definition of the filters of a DYNAMIC or SNAPSHOT list, and is only used on Create.
+	// Reference: https://developers.hubspot.com/docs/api/crm/lists-filters
+	FilterBranch interface{} `json:"filterBranch,omitempty"`
+}
+
+// Dynamic reports whether the members of the list are managed by HubSpot from its filters.
+// The members of a dynamic list cannot be added or removed.
+func (l *List) Dynamic() bool {
+	return l.ProcessingType == ListProcessingTypeDynamic
+}
+
+type listResponse struct {
+	List *List `json:"list"`
+}
+
+type listSearchRequest struct {
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+type listSearchResponse struct {
+	Lists   []*List `json:"lists"`
+	HasMore bool    `json:"hasMore"`
+	Offset  int     `json:"offset"`
+}
+
+type listMembership struct {
+	RecordID string `json:"recordId"`
+}
+
+type listMembershipsResponse struct {
+	Results []listMembership `json:"results"`
+	Paging  *Paging          `json:"paging,omitempty"`
+}
+
+// Create creates a new list.
+// Name, ObjectTypeID such as ListObjectTypeIDContact and ProcessingType are required.
+// A static list is created with ListProcessingTypeManual, and a dynamic list with ListProcessingTypeDynamic and FilterBranch.
+func (s *ListServiceOp) Create(list *List) (*List, error) {
+	resource := &listResponse{}
+	if err := s.client.Post(s.listPath, list, resource); err != nil {
+		return nil, err
+	}
+	return resource.List, nil
+}
+
+// Get gets a list.
+// Use List.Dynamic to check whether its members can be added or removed.
+func (s *ListServiceOp) Get(listID string) (*List, error) {
+	resource := &listResponse{}
+	if err := s.client.Get(s.listPath+"/"+listID, resource, nil); err != nil {
+		return nil, err
+	}
+	return resource.List, nil
+}
+
+// GetAll gets all lists, of both static and dynamic lists, following the pages until the last one.
+func (s *ListServiceOp) GetAll() ([]*List, error) {
+	lists := []*List{}
+	req := &listSearchRequest{Count: listSearchPageLimit}
+	for {
+		page := &listSearchResponse{}
+		if err := s.client.Post(s.listPath+"/search", req, page); err != nil {
+			return nil, err
+		}
+		lists = append(lists, page.Lists...)
+		if !page.HasMore || len(page.Lists) == 0 {
+			return lists, nil
+		}
+		req.Offset = page.Offset
+	}
+}
+
+// Delete deletes a list.
+func (s *ListServiceOp) Delete(listID string) error {
+	return s.client.Delete(s.listPath + "/" + listID)
+}
+
+// AddMembers adds the records of the given IDs to a list.
+// The list must be a MANUAL or SNAPSHOT list, HubSpot rejects the request for a DYNAMIC list.
+// IDs of records that do not exist are ignored by HubSpot.
+func (s *ListServiceOp) AddMembers(listID string, ids []string) error {
+	return s.client.Put(s.listPath+"/"+listID+"/memberships/add", ids, nil)
+}
+
+// RemoveMembers removes the records of the given IDs from a list.
+// The list must be a MANUAL or SNAPSHOT list, HubSpot rejects the request for a DYNAMIC list.
+func (s *ListServiceOp) RemoveMembers(listID string, ids []string) error {
+	return s.client.Put(s.listPath+"/"+listID+"/memberships/remove", ids, nil)
+}
+
+// Members gets all members of a list, following the pages until the last one.
+// It works for both static and dynamic lists.
+// Only ResponseResource.ID is set to the ID of each member record, use the object service to get its properties.
+func (s *ListServiceOp) Members(listID string) (*ResponseResourceMulti, error) {
+	result := &ResponseResourceMulti{Results: []ResponseResource{}}
+	path := s.listPath + "/" + listID + "/memberships"
+	option := &RequestQueryOption{Limit: listMembersPageLimit}
+	for {
+		page := &listMembershipsResponse{}
+		if err := s.client.Get(path, page, option); err != nil {
+			return nil, err
+		}
+		for _, m := range page.Results {
+			result.Results = append(result.Results, ResponseResource{ID: m.RecordID})
+		}
+		next, nextOption, ok, err := s.client.nextPage(page.Paging, path, option)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return result, nil
+		}
+		path, option = next, nextOption
+	}
+}
@@ -0,0 +1,246 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestListServiceOp_Get(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"list":{"listId":"123","name":"Trial companies","objectTypeId":"0-2","processingType":"DYNAMIC","processingStatus":"COMPLETE","size":2,"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}}`),
+	}
+	want := &hubspot.List{
+		ListID:           "123",
+		Name:             "Trial companies",
+		ObjectTypeID:     "0-2",
+		ProcessingType:   hubspot.ListProcessingTypeDynamic,
+		ProcessingStatus: "COMPLETE",
+		Size:             2,
+		CreatedAt:        &createdAt,
+		UpdatedAt:        &updatedAt,
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.List.Get("123")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	if !got.Dynamic() {
+		t.Error("Dynamic() mismatch: want true got false")
+	}
+}
+
+func TestListServiceOp_AddMembers(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"recordIdsAdded":["company001","company002"],"recordIdsMissing":[]}`),
+	}
+
+	if err := hubspot.NewMockClient(conf).CRM.List.AddMembers("123", []string{"company001", "company002"}); err != nil {
+		t.Fatalf("AddMembers() unexpected error: %s", err)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/lists/123/memberships/add"; req.Method != http.MethodPut || req.URL.Path != want {
+		t.Errorf("AddMembers() request mismatch: want PUT %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	if want := `["company001","company002"]`; string(req.Body) != want {
+		t.Errorf("AddMembers() request body mismatch: want %s got %s", want, string(req.Body))
+	}
+}
+
+func TestListServiceOp_Members(t *testing.T) {
+	pages := map[string][]byte{
+		"":      []byte(`{"results":[{"recordId":"company001","membershipTimestamp":"2019-10-30T03:30:17.883Z"},{"recordId":"company002","membershipTimestamp":"2019-10-30T03:30:17.883Z"}],"paging":{"next":{"after":"page2"}}}`),
+		"page2": []byte(`{"results":[{"recordId":"company003","membershipTimestamp":"2019-10-30T03:30:17.883Z"}]}`),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{{ID: "company001"}, {ID: "company002"}, {ID: "company003"}},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages)).CRM.List.Members("123")
+	if err != nil {
+		t.Fatalf("Members() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Members() response mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestListServiceOp_Members_PagingLink(t *testing.T) {
+	pages := map[string][]byte{
+		"":      []byte(`{"results":[{"recordId":"company001"}],"paging":{"next":{"link":"https://api.hubapi.com/crm/v3/lists/123/memberships?limit=250&after=page2"}}}`),
+		"page2": []byte(`{"results":[{"recordId":"company002"}]}`),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{{ID: "company001"}, {ID: "company002"}},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages)).CRM.List.Members("123")
+	if err != nil {
+		t.Fatalf("Members() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Members() response mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestListServiceOp_Members_PagingLinkWithoutCursor(t *testing.T) {
+	pages := map[string][]byte{
+		"":  []byte(`{"results":[{"recordId":"company001"}],"paging":{"next":{"link":"https://api.hubapi.com/crm/v3/lists/123/memberships?limit=250&offset=1"}}}`),
+		"1": []byte(`{"results":[{"recordId":"company002"}]}`),
+	}
+	var queries []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			queries = append(queries, req.URL.RawQuery)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(pages[req.URL.Query().Get("offset")])),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{{ID: "company001"}, {ID: "company002"}},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.List.Members("123")
+	if err != nil {
+		t.Fatalf("Members() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Members() response mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{"limit=250", "limit=250&offset=1"}, queries); diff != "" {
+		t.Errorf("Members() queries mismatch (-want +got):%s", diff)
+	}
+
+	pages[""] = []byte(`{"results":[{"recordId":"company001"}],"paging":{"next":{"link":"https://example.com/crm/v3/lists/123/memberships?limit=250&offset=1"}}}`)
+	queries = nil
+	if _, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.List.Members("123"); !errors.Is(err, hubspot.ErrPagingLinkHost) {
+		t.Errorf("Members() error mismatch: want ErrPagingLinkHost got %v", err)
+	}
+	if len(queries) != 1 {
+		t.Errorf("Members() requests mismatch: want 1 request got %d", len(queries))
+	}
+}
+
+func TestListServiceOp_Members_PagingLinkCustomBaseURL(t *testing.T) {
+	first := []byte(`{"results":[{"recordId":"company001"}],"paging":{"next":{"link":"http://localhost:8080/crm/v3/lists/123/memberships?limit=250&after=page2"}}}`)
+	pages := map[string][]byte{
+		"":      first,
+		"page2": []byte(`{"results":[{"recordId":"company002"}]}`),
+	}
+	var hosts []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			hosts = append(hosts, req.URL.Host)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(pages[req.URL.Query().Get("after")])),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	c, err := hubspot.NewClient(hubspot.SetAPIKey("key"), hubspot.WithHTTPClient(httpClient), hubspot.WithBaseURL("http://localhost:8080"))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %s", err)
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{{ID: "company001"}, {ID: "company002"}},
+	}
+
+	got, err := c.CRM.List.Members("123")
+	if err != nil {
+		t.Fatalf("Members() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Members() response mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{"localhost:8080", "localhost:8080"}, hosts); diff != "" {
+		t.Errorf("Members() hosts mismatch (-want +got):%s", diff)
+	}
+
+	// NextCursor accepts the same link as the client when given the same base URL.
+	page := &hubspot.ResponseResourceMulti{}
+	if err := json.Unmarshal(first, page); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %s", err)
+	}
+	if cursor, ok := page.NextCursor("http://localhost:8080"); cursor != "page2" || !ok {
+		t.Errorf("NextCursor() mismatch: want (%q, true) got (%q, %v)", "page2", cursor, ok)
+	}
+	if _, ok := page.NextCursor(""); ok {
+		t.Errorf("NextCursor() mismatch: want no cursor for the default base URL")
+	}
+}
+
+func TestListServiceOp_Create(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"list":{"listId":"124","name":"Newsletter","objectTypeId":"0-1","processingType":"MANUAL","size":0}}`),
+	}
+	list := &hubspot.List{Name: "Newsletter", ObjectTypeID: hubspot.ListObjectTypeIDContact, ProcessingType: hubspot.ListProcessingTypeManual}
+	want := &hubspot.List{ListID: "124", Name: "Newsletter", ObjectTypeID: "0-1", ProcessingType: hubspot.ListProcessingTypeManual}
+	wantBody := `{"name":"Newsletter","objectTypeId":"0-1","processingType":"MANUAL"}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.List.Create(list)
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Create() response mismatch (-want +got):%s", diff)
+	}
+	if string(conf.Requests[0].Body) != wantBody {
+		t.Errorf("Create() request body mismatch: want %s got %s", wantBody, string(conf.Requests[0].Body))
+	}
+	if got.Dynamic() {
+		t.Error("Dynamic() mismatch: want false got true")
+	}
+}
+
+func TestListServiceOp_GetAll(t *testing.T) {
+	responses := []string{
+		`{"lists":[{"listId":"1","name":"Static","processingType":"MANUAL"}],"hasMore":true,"offset":1,"total":2}`,
+		`{"lists":[{"listId":"2","name":"Active","processingType":"DYNAMIC"}],"hasMore":false,"offset":2,"total":2}`,
+	}
+	var bodies []string
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(responses[len(bodies)-1])),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	want := []*hubspot.List{
+		{ListID: "1", Name: "Static", ProcessingType: hubspot.ListProcessingTypeManual},
+		{ListID: "2", Name: "Active", ProcessingType: hubspot.ListProcessingTypeDynamic},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(httpClient).CRM.List.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]string{`{"offset":0,"count":500}`, `{"offset":1,"count":500}`}, bodies); diff != "" {
+		t.Errorf("GetAll() request body mismatch (-want +got):%s", diff)
+	}
+}
@@ -0,0 +1,303 @@
+package hubspot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// propertyTag is the struct tag mapping a field to a HubSpot property independently of its `json` tag,
+// e.g. `json:"trialStatus" hubspot:"trial_status,omitempty"`.
+// A struct using it anywhere, including in embedded structs, is sent and received with the mapping:
+// the fields with the tag use its name, the other fields use their `json` tag as usual, and `hubspot:"-"` is ignored.
+// The structs without the tag are encoded with encoding/json as they are.
+const propertyTag = "hubspot"
+
+// propertyField is a field of a properties structure mapped to a HubSpot property.
+type propertyField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+}
+
+// propertyMapping is the mapping of a properties structure, set only if the structure uses the hubspot tag.
+type propertyMapping struct {
+	fields []propertyField
+}
+
+var propertyMappings sync.Map // map[reflect.Type]*propertyMapping
+
+// propertyMappingOf returns the mapping of the structure v points to, or nil if it does not use the hubspot tag.
+func propertyMappingOf(v interface{}) *propertyMapping {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	if m, ok := propertyMappings.Load(t); ok {
+		return m.(*propertyMapping)
+	}
+	var m *propertyMapping
+	if fields, tagged := mapPropertyFields(t, nil); tagged {
+		m = &propertyMapping{fields: fields}
+	}
+	propertyMappings.Store(t, m)
+	return m
+}
+
+// PropertiesFromStruct returns the names of the properties of the structure v points to, in the order of its fields,
+// so that the properties to get stay in sync with the structure, e.g.
+// option.Properties = hubspot.PropertiesFromStruct(&hubspot.Company{})
+// The names are those the properties are encoded with: the `hubspot` tag if set, the `json` tag otherwise,
+// including the fields of the embedded structures, and the fields tagged with "-" are skipped.
+// It returns nil if v is not a structure or a pointer to one.
+func PropertiesFromStruct(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	fields, _ := mapPropertyFields(t, nil)
+	names := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		// A field of an embedded structure may be shadowed by a field of the same name.
+		if !seen[f.name] {
+			seen[f.name] = true
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// DiffProperties returns the properties of desired whose value differs from that of current, e.g. the state of
+// a company got from HubSpot, so that only the changed properties are passed to Update and no workflow is re-run
+// for the others. current and desired are properties structures such as *Company, or maps, not necessarily of the same type.
+// The properties are compared as they are encoded, with the hubspot tag if set, so the properties omitted from desired,
+// such as nil fields with omitempty, or set to null are left as they are. Set a field to ClearString() to clear the property.
+// The values are returned as they are encoded, a string for HsStr and a json.Number for numbers.
+// It returns an empty map if no property differs, and an error if current or desired is not encoded to a JSON object.
+func DiffProperties(current, desired interface{}) (map[string]interface{}, error) {
+	currentProperties, err := encodedProperties(current)
+	if err != nil {
+		return nil, fmt.Errorf("current: %w", err)
+	}
+	desiredProperties, err := encodedProperties(desired)
+	if err != nil {
+		return nil, fmt.Errorf("desired: %w", err)
+	}
+	diff := map[string]interface{}{}
+	for name, value := range desiredProperties {
+		if value == nil {
+			continue
+		}
+		if old, ok := currentProperties[name]; !ok || !reflect.DeepEqual(old, value) {
+			diff[name] = value
+		}
+	}
+	return diff, nil
+}
+
+// encodedProperties encodes the properties as they are sent to HubSpot and decodes them into a map,
+// keeping the numbers as json.Number so that they are compared and sent exactly.
+func encodedProperties(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(withPropertyMapping(v))
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var properties map[string]interface{}
+	if err := dec.Decode(&properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// mapPropertyFields lists the fields of the structure, flattening the embedded structures as encoding/json does,
+// and reports whether any of them uses the hubspot tag.
+func mapPropertyFields(t reflect.Type, index []int) ([]propertyField, bool) {
+	var fields []propertyField
+	tagged := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+		hsTag, hasHsTag := f.Tag.Lookup(propertyTag)
+		jsonTag, hasJSONTag := f.Tag.Lookup("json")
+		tagged = tagged || hasHsTag
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && ft.Kind() == reflect.Struct && !hasHsTag && (!hasJSONTag || strings.Split(jsonTag, ",")[0] == "") {
+			embedded, embeddedTagged := mapPropertyFields(ft, fieldIndex)
+			fields = append(fields, embedded...)
+			tagged = tagged || embeddedTagged
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		tag := jsonTag
+		if hasHsTag {
+			tag = hsTag
+		}
+		parts := strings.Split(tag, ",")
+		if parts[0] == "-" && len(parts) == 1 {
+			continue
+		}
+		name := parts[0]
+		if name == "" {
+			name = f.Name
+		}
+		field := propertyField{index: fieldIndex, name: name}
+		for _, option := range parts[1:] {
+			if option == "omitempty" {
+				field.omitEmpty = true
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, tagged
+}
+
+// mappedProperties encodes and decodes a properties structure using the hubspot tag.
+type mappedProperties struct {
+	v       interface{}
+	mapping *propertyMapping
+}
+
+// withPropertyMapping returns the properties to encode, which are wrapped to use the mapping if they use the hubspot tag.
+func withPropertyMapping(v interface{}) interface{} {
+	if m := propertyMappingOf(v); m != nil {
+		return &mappedProperties{v: v, mapping: m}
+	}
+	return v
+}
+
+func (p *mappedProperties) MarshalJSON() ([]byte, error) {
+	rv := reflect.ValueOf(p.v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return []byte("null"), nil
+		}
+		rv = rv.Elem()
+	}
+	properties := make(map[string]json.RawMessage, len(p.mapping.fields))
+	for _, f := range p.mapping.fields {
+		fv, ok := fieldByIndex(rv, f.index, false)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		b, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return nil, err
+		}
+		properties[f.name] = b
+	}
+	return json.Marshal(properties)
+}
+
+func (p *mappedProperties) UnmarshalJSON(b []byte) error {
+	rv := reflect.ValueOf(p.v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return json.Unmarshal(b, p.v)
+	}
+	rv = rv.Elem()
+	properties := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return err
+	}
+	for _, f := range p.mapping.fields {
+		raw, ok := properties[f.name]
+		if !ok {
+			continue
+		}
+		fv, _ := fieldByIndex(rv, f.index, true)
+		if err := json.Unmarshal(raw, fv.Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex returns the field of the index, following the embedded pointers.
+// A nil embedded pointer is allocated if alloc is true, otherwise the field is reported missing.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyValue reports whether the value is omitted by omitempty, in the same way as encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// MarshalJSON encodes the payload, mapping the properties with the hubspot tag if they use it.
+func (p RequestPayload) MarshalJSON() ([]byte, error) {
+	type payload RequestPayload
+	out := payload(p)
+	out.Properties = withPropertyMapping(p.Properties)
+	return json.Marshal(out)
+}
+
+// MarshalJSON encodes the input, mapping the properties with the hubspot tag if they use it.
+func (in BatchUpsertInput) MarshalJSON() ([]byte, error) {
+	type input BatchUpsertInput
+	out := input(in)
+	out.Properties = withPropertyMapping(in.Properties)
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the resource, mapping the properties with the hubspot tag if the bound structure uses it.
+func (r *ResponseResource) UnmarshalJSON(b []byte) error {
+	type resource ResponseResource
+	m := propertyMappingOf(r.Properties)
+	if m == nil {
+		return json.Unmarshal(b, (*resource)(r))
+	}
+	properties := r.Properties
+	aux := &struct {
+		*resource
+		Properties *mappedProperties `json:"properties,omitempty"`
+	}{resource: (*resource)(r), Properties: &mappedProperties{v: properties, mapping: m}}
+	if err := json.Unmarshal(b, aux); err != nil {
+		return err
+	}
+	r.Properties = properties
+	return nil
+}
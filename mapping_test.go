@@ -0,0 +1,172 @@
+package hubspot_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+type mappedCompany struct {
+	hubspot.Company
+	PlanTier    *hubspot.HsStr `json:"planTier,omitempty" hubspot:"plan_tier,omitempty"`
+	Seats       *hubspot.HsInt `json:"-" hubspot:"seats,omitempty"`
+	Note        string         `json:"note" hubspot:"-"`
+}
+
+func TestPropertyTag(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"name":"Acme","plan_tier":"pro","seats":"12","note":"ignored"}}`),
+	}
+	company := &mappedCompany{
+		Company:     hubspot.Company{Name: hubspot.NewString("Acme")},
+		PlanTier:    hubspot.NewString("pro"),
+		Seats:       hubspot.NewInt(12),
+		Note:        "internal",
+	}
+	want := &hubspot.ResponseResource{
+		ID: "company001",
+		Properties: &mappedCompany{
+			Company:     hubspot.Company{Name: hubspot.NewString("Acme")},
+			PlanTier:    hubspot.NewString("pro"),
+			Seats:       hubspot.NewInt(12),
+			// The response is bound to the created company, and the note property is ignored.
+			Note: "internal",
+		},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Company.Create(company)
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Create() response mismatch (-want +got):%s", diff)
+	}
+	if want := `{"properties":{"name":"Acme","plan_tier":"pro","seats":12}}`; string(conf.Requests[0].Body) != want {
+		t.Errorf("Create() request body mismatch: want %s got %s", want, string(conf.Requests[0].Body))
+	}
+
+	// The json tags are kept for the serialization of the application.
+	b, err := json.Marshal(company)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %s", err)
+	}
+	if want := `{"name":"Acme","planTier":"pro","note":"internal"}`; string(b) != want {
+		t.Errorf("json.Marshal() mismatch: want %s got %s", want, string(b))
+	}
+}
+
+func TestPropertiesFromStruct(t *testing.T) {
+	type baseCompany struct {
+		Name   *hubspot.HsStr `json:"name,omitempty"`
+		Domain *hubspot.HsStr `json:"domain,omitempty"`
+	}
+	type customCompany struct {
+		baseCompany
+		ExternalID *hubspot.HsStr `json:"external_id,omitempty"`
+		Name       *hubspot.HsStr `json:"name,omitempty"`
+		Internal   string         `json:"-"`
+	}
+	type mappedCustomCompany struct {
+		baseCompany
+		PlanTier *hubspot.HsStr `json:"planTier,omitempty" hubspot:"plan_tier,omitempty"`
+		Seats    *hubspot.HsInt `json:"-" hubspot:"seats,omitempty"`
+		Note     string         `json:"note" hubspot:"-"`
+	}
+
+	tests := []struct {
+		name string
+		v    interface{}
+		want []string
+	}{
+		{
+			name: "json tags of embedded structure",
+			v:    &customCompany{},
+			want: []string{"name", "domain", "external_id"},
+		},
+		{
+			name: "hubspot tags",
+			v:    mappedCustomCompany{},
+			want: []string{"name", "domain", "plan_tier", "seats"},
+		},
+		{
+			name: "Not a structure",
+			v:    map[string]string{},
+		},
+		{
+			name: "Nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, hubspot.PropertiesFromStruct(tt.v)); diff != "" {
+				t.Errorf("PropertiesFromStruct() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffProperties(t *testing.T) {
+	current := &mappedCompany{
+		Company:  hubspot.Company{Name: hubspot.NewString("Acme"), Domain: hubspot.NewString("acme.com")},
+		PlanTier: hubspot.NewString("free"),
+		Seats:    hubspot.NewInt(5),
+	}
+	tests := []struct {
+		name    string
+		current interface{}
+		desired interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "Only the changed properties",
+			current: current,
+			desired: &mappedCompany{
+				Company:  hubspot.Company{Name: hubspot.NewString("Acme"), Domain: hubspot.NewString("acme.io")},
+				PlanTier: hubspot.NewString("free"),
+				Seats:    hubspot.NewInt(10),
+			},
+			want: map[string]interface{}{"domain": "acme.io", "seats": json.Number("10")},
+		},
+		{
+			name:    "Omitted properties are left as they are",
+			current: current,
+			desired: &mappedCompany{PlanTier: hubspot.NewString("pro")},
+			want:    map[string]interface{}{"plan_tier": "pro"},
+		},
+		{
+			name:    "Properties not set in current",
+			current: &hubspot.Company{},
+			desired: map[string]interface{}{"name": "Acme", "custom": "x"},
+			want:    map[string]interface{}{"name": "Acme", "custom": "x"},
+		},
+		{
+			name:    "No change",
+			current: current,
+			desired: &hubspot.Company{Name: hubspot.NewString("Acme")},
+			want:    map[string]interface{}{},
+		},
+		{
+			name:    "Failed with desired not a structure",
+			current: current,
+			desired: []string{"name"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hubspot.DiffProperties(tt.current, tt.desired)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffProperties() error mismatch: want error %t got %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffProperties() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package hubspot
+
+import "fmt"
+
+const (
+	marketingBasePath = "marketing"
+)
+
+type Marketing struct {
+	Form FormService
+}
+
+func newMarketing(c *Client) *Marketing {
+	marketingPath := fmt.Sprintf("%s/%s", marketingBasePath, c.apiVersion)
+	return &Marketing{
+		Form: &FormServiceOp{
+			formPath: fmt.Sprintf("%s/%s", marketingPath, formBasePath),
+			client:   c,
+		},
+	}
+}
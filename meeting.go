@@ -0,0 +1,123 @@
+package hubspot
+
+const (
+	meetingBasePath = "meetings"
+)
+
+// MeetingService is an interface of meeting engagement endpoints of the HubSpot API.
+// HubSpot meeting engagements log meetings held with contacts.
+// It can also be associated with other CRM objects such as contact, company and deal.
+// Reference: https://developers.hubspot.com/docs/api/crm/meetings
+type MeetingService interface {
+	Get(meetingID string, meeting interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	Create(meeting interface{}) (*ResponseResource, error)
+	CreateWithAssociations(meeting interface{}, associations []CreateAssociation) (*ResponseResource, error)
+	Update(meetingID string, meeting interface{}) (*ResponseResource, error)
+	Delete(meetingID string) error
+}
+
+// MeetingServiceOp handles communication with the meeting engagement related methods of the HubSpot API.
+type MeetingServiceOp struct {
+	meetingPath string
+	client      *Client
+}
+
+var _ MeetingService = (*MeetingServiceOp)(nil)
+
+// Meeting outcomes
+const (
+	MeetingOutcomeScheduled   = "SCHEDULED"
+	MeetingOutcomeCompleted   = "COMPLETED"
+	MeetingOutcomeRescheduled = "RESCHEDULED"
+	MeetingOutcomeNoShow      = "NO_SHOW"
+	MeetingOutcomeCanceled    = "CANCELED"
+)
+
+// Meeting represents a HubSpot meeting engagement.
+type Meeting struct {
+	HsTimestamp            *HsTime `json:"hs_timestamp,omitempty"`
+	HsMeetingTitle         *HsStr  `json:"hs_meeting_title,omitempty"`
+	HsMeetingBody          *HsStr  `json:"hs_meeting_body,omitempty"`
+	HsMeetingStartTime     *HsTime `json:"hs_meeting_start_time,omitempty"`
+	HsMeetingEndTime       *HsTime `json:"hs_meeting_end_time,omitempty"`
+	HsMeetingLocation      *HsStr  `json:"hs_meeting_location,omitempty"`
+	HsMeetingOutcome       *HsStr  `json:"hs_meeting_outcome,omitempty"`
+	HsInternalMeetingNotes *HsStr  `json:"hs_internal_meeting_notes,omitempty"`
+	HubspotOwnerID         *HsStr  `json:"hubspot_owner_id,omitempty"`
+	HsObjectID             *HsStr  `json:"hs_object_id,omitempty"`
+	HsCreateDate           *HsTime `json:"hs_createdate,omitempty"`
+	HsLastModifiedDate     *HsTime `json:"hs_lastmodifieddate,omitempty"`
+}
+
+var defaultMeetingFields = []string{
+	"hs_timestamp",
+	"hs_meeting_title",
+	"hs_meeting_body",
+	"hs_meeting_start_time",
+	"hs_meeting_end_time",
+	"hs_meeting_location",
+	"hs_meeting_outcome",
+	"hs_internal_meeting_notes",
+	"hubspot_owner_id",
+	"hs_object_id",
+	"hs_createdate",
+	"hs_lastmodifieddate",
+}
+
+// Get gets a meeting engagement.
+// In order to bind the get content, a structure must be specified as an argument.
+// Also, if you want to gets a custom field, you need to specify the field name.
+// If you specify a non-existent field, it will be ignored.
+// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+func (s *MeetingServiceOp) Get(meetingID string, meeting interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: meeting}
+	if err := s.client.Get(s.meetingPath+"/"+meetingID, resource, option.setupProperties(defaultMeetingFields)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Create creates a new meeting engagement.
+// In order to bind the created content, a structure must be specified as an argument.
+// When using custom fields, please embed hubspot.Meeting in your own structure.
+// If hs_timestamp is not set, it is set to the current time, as HubSpot rejects the engagements without it.
+// Set HsTimestamp to log an engagement that happened at another time.
+func (s *MeetingServiceOp) Create(meeting interface{}) (*ResponseResource, error) {
+	return s.CreateWithAssociations(meeting, nil)
+}
+
+// CreateWithAssociations creates a new meeting engagement and associates it with other objects in the same request.
+// In order to bind the created content, a structure must be specified as an argument.
+// hs_timestamp defaults to the current time in the same way as Create.
+func (s *MeetingServiceOp) CreateWithAssociations(meeting interface{}, associations []CreateAssociation) (*ResponseResource, error) {
+	properties, err := withDefaultTimestamp(meeting)
+	if err != nil {
+		return nil, err
+	}
+	req := &RequestPayload{Properties: properties, Associations: associations}
+	resource := &ResponseResource{Properties: meeting}
+	if err := s.client.Post(s.meetingPath, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Update updates a meeting engagement.
+// In order to bind the updated content, a structure must be specified as an argument.
+// When using custom fields, please embed hubspot.Meeting in your own structure.
+func (s *MeetingServiceOp) Update(meetingID string, meeting interface{}) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: meeting}
+	resource := &ResponseResource{Properties: meeting}
+	if err := s.client.Patch(s.meetingPath+"/"+meetingID, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Delete deletes a meeting engagement.
+func (s *MeetingServiceOp) Delete(meetingID string) error {
+	if err := s.client.Delete(s.meetingPath + "/" + meetingID); err != nil {
+		return err
+	}
+	return nil
+}
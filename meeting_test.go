@@ -0,0 +1,60 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestMeetingServiceOp_CreateWithAssociations(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"meeting001","properties":{"hs_meeting_title":"Kickoff","hs_meeting_start_time":"2019-10-30T03:30:17.883Z","hs_meeting_end_time":"2019-12-07T16:50:06.678Z","hs_object_id":"meeting001","hs_timestamp":"2019-10-30T03:30:17.883Z"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z","archived":false}`),
+	}
+	meeting := &hubspot.Meeting{
+		HsTimestamp:        hubspot.NewTime(time.Date(2019, 10, 30, 3, 30, 17, 883000000, time.UTC)),
+		HsMeetingTitle:     hubspot.NewString("Kickoff"),
+		HsMeetingStartTime: hubspot.NewTime(time.Date(2019, 10, 30, 3, 30, 17, 883000000, time.UTC)),
+		HsMeetingEndTime:   hubspot.NewTime(time.Date(2019, 12, 7, 16, 50, 6, 678000000, time.UTC)),
+	}
+	associations := []hubspot.CreateAssociation{
+		{
+			To: hubspot.AssociationTo{ID: "deal001"},
+			Types: []hubspot.AssociationSpec{
+				{Category: hubspot.AssociationCategoryHubSpotDefined, TypeID: hubspot.AssociationTypeIDMeetingToDeal},
+			},
+		},
+	}
+	want := &hubspot.ResponseResource{
+		ID: "meeting001",
+		Properties: &hubspot.Meeting{
+			HsTimestamp:        &createdAt,
+			HsMeetingTitle:     hubspot.NewString("Kickoff"),
+			HsMeetingStartTime: &createdAt,
+			HsMeetingEndTime:   &updatedAt,
+			HsObjectID:         hubspot.NewString("meeting001"),
+		},
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+	wantBody := `{"properties":{"hs_timestamp":"2019-10-30T03:30:17.883Z","hs_meeting_title":"Kickoff","hs_meeting_start_time":"2019-10-30T03:30:17.883Z","hs_meeting_end_time":"2019-12-07T16:50:06.678Z"},"associations":[{"to":{"id":"deal001"},"types":[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":212}]}]}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Meeting.CreateWithAssociations(meeting, associations)
+	if err != nil {
+		t.Fatalf("CreateWithAssociations() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("CreateWithAssociations() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/meetings"; req.URL.Path != want {
+		t.Errorf("CreateWithAssociations() request path mismatch: want %s got %s", want, req.URL.Path)
+	}
+	if string(req.Body) != wantBody {
+		t.Errorf("CreateWithAssociations() request body mismatch: want %s got %s", wantBody, string(req.Body))
+	}
+}
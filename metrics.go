@@ -0,0 +1,49 @@
+package hubspot
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Metrics observes the requests made by the Client, e.g. to export them to Prometheus or OpenTelemetry.
+// The methods are called synchronously from the goroutine making the request, so they should return quickly,
+// and must be safe for concurrent use when requests are made concurrently.
+type Metrics interface {
+	// ObserveRequest is called after each request with the templated path, e.g. "/crm/v3/objects/companies/{id}".
+	// The status is 0 if no response was received, e.g. on a network error or a timeout.
+	ObserveRequest(method, path string, status int, dur time.Duration)
+	// ObserveRetry is called before each retry of a call rejected by HubSpot, with the attempt number starting at 1.
+	ObserveRetry(attempt int)
+}
+
+// idSegmentPattern matches the path segments that are IDs, such as numeric object IDs, GUIDs and email addresses.
+var idSegmentPattern = regexp.MustCompile(`^(?:\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[^@]+@[^@]+)$`)
+
+// templatePath replaces the ID segments of the path with "{id}", so that the metrics keep a low cardinality.
+func templatePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if idSegmentPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// observeRequest reports a request to the metrics, with status 0 if no response was received.
+func (c *Client) observeRequest(req *http.Request, resp *http.Response, start time.Time) {
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	c.metrics.ObserveRequest(req.Method, templatePath(req.URL.Path), status, time.Since(start))
+}
+
+// observeRetry reports a retry to the metrics if set.
+func (c *Client) observeRetry(attempt int) {
+	if c.metrics != nil {
+		c.metrics.ObserveRetry(attempt)
+	}
+}
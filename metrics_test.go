@@ -0,0 +1,83 @@
+package hubspot_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []string
+	retries  []int
+}
+
+func (m *recordingMetrics) ObserveRequest(method, path string, status int, dur time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, method+" "+path+" "+http.StatusText(status))
+}
+
+func (m *recordingMetrics) ObserveRetry(attempt int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, attempt)
+}
+
+func TestWithMetrics(t *testing.T) {
+	backoff := *hubspot.ExportRateLimitBackoff
+	*hubspot.ExportRateLimitBackoff = time.Millisecond
+	defer func() { *hubspot.ExportRateLimitBackoff = backoff }()
+
+	conf := &hubspot.MockConfig{
+		Status: http.StatusNotFound,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"error","message":"Object not found.","category":"OBJECT_NOT_FOUND"}`),
+	}
+	m := &recordingMetrics{}
+	c := hubspot.NewMockClient(conf)
+	if err := hubspot.WithMetrics(m)(c); err != nil {
+		t.Fatalf("WithMetrics() unexpected error: %s", err)
+	}
+
+	_, _ = c.CRM.Company.Get("512", &hubspot.Company{}, &hubspot.RequestQueryOption{})
+	var calls int
+	_ = c.RunConcurrent(context.Background(), []func() error{func() error {
+		calls++
+		if calls == 1 {
+			return &hubspot.APIError{HTTPStatusCode: http.StatusTooManyRequests}
+		}
+		return nil
+	}}, 1)
+
+	if diff := cmp.Diff([]string{"GET /crm/v3/objects/companies/{id} Not Found"}, m.requests); diff != "" {
+		t.Errorf("ObserveRequest() mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff([]int{1}, m.retries); diff != "" {
+		t.Errorf("ObserveRetry() mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/crm/v3/objects/companies", want: "/crm/v3/objects/companies"},
+		{path: "/crm/v3/objects/companies/512/associations/contacts", want: "/crm/v3/objects/companies/{id}/associations/contacts"},
+		{path: "/crm/v3/objects/contacts/hubspot@example.com", want: "/crm/v3/objects/contacts/{id}"},
+		{path: "/submissions/v3/integration/submit/123456/0e4d2f0a-4a5b-4c3d-9e8f-1a2b3c4d5e6f", want: "/submissions/v3/integration/submit/{id}/{id}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := hubspot.ExportTemplatePath(tt.path); got != tt.want {
+				t.Errorf("templatePath() mismatch: want %s got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -19,25 +20,68 @@ type MockConfig struct {
 	Status int
 	Header http.Header
 	Body   []byte
+
+	// Requests records the requests received by the mock HTTP client.
+	Requests []*MockRequest
+}
+
+type MockRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
 }
 
 // Client
 
 func NewMockClient(conf *MockConfig) *Client {
+	return NewMockClientWithHTTPClient(NewMockHTTPClient(conf))
+}
+
+func NewMockClientWithHTTPClient(httpClient *http.Client) *Client {
 	cli := &Client{
-		HTTPClient: NewMockHTTPClient(conf),
+		HTTPClient: httpClient,
 		baseURL:    defaultBaseURL,
 		apiVersion: defaultAPIVersion,
 	}
 	cli.CRM = newCRM(cli)
+	cli.Files = newFiles(cli)
+	cli.Marketing = newMarketing(cli)
 	SetAPIKey("apikey")(cli)
 
 	return cli
 }
 
+// NewMockPagesHTTPClient returns a mock HTTP client that responds with the page body keyed by the `after` query parameter.
+// The first page is keyed by an empty string.
+func NewMockPagesHTTPClient(pages map[string][]byte) *http.Client {
+	return &http.Client{
+		Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+			body, ok := pages[req.URL.Query().Get("after")]
+			if !ok {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"message":"page not found"}`)),
+					Header:     http.Header{},
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBuffer(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+}
+
 func NewMockHTTPClient(conf *MockConfig) *http.Client {
 	return &http.Client{
 		Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+			mr := &MockRequest{Method: req.Method, URL: req.URL, Header: req.Header}
+			if req.Body != nil {
+				mr.Body, _ = ioutil.ReadAll(req.Body)
+			}
+			conf.Requests = append(conf.Requests, mr)
 			return &http.Response{
 				StatusCode: conf.Status,
 				Body:       ioutil.NopCloser(bytes.NewBuffer(conf.Body)),
@@ -0,0 +1,103 @@
+package hubspot
+
+import "fmt"
+
+// ObjectService is an interface of the generic object endpoints of the HubSpot API.
+// HubSpot handles all object types uniformly under crm/v3/objects/{objectType}, so this works for any object type,
+// including the custom objects that have no service in this package.
+// The objectType is the name or the ID of the object type, e.g. hubspot.ObjectTypeCompany, "2-123456" or "p_cars".
+// Since there are no default properties, HubSpot returns only a few of them unless RequestQueryOption.Properties
+// or RequestQueryOption.CustomProperties are specified.
+// Reference: https://developers.hubspot.com/docs/api/crm/understanding-the-crm
+type ObjectService interface {
+	Get(objectType ObjectType, objectID string, object interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetAll(objectType ObjectType, object interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error)
+	Search(objectType ObjectType, object interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error)
+	Create(objectType ObjectType, object interface{}) (*ResponseResource, error)
+	Update(objectType ObjectType, objectID string, object interface{}) (*ResponseResource, error)
+	Delete(objectType ObjectType, objectID string) error
+}
+
+// ObjectServiceOp handles communication with the generic object related methods of the HubSpot API.
+type ObjectServiceOp struct {
+	objectPath string
+	client     *Client
+}
+
+var _ ObjectService = (*ObjectServiceOp)(nil)
+
+func (s *ObjectServiceOp) path(objectType ObjectType) string {
+	return fmt.Sprintf("%s/%s", s.objectPath, objectType)
+}
+
+// Get gets an object of the object type.
+// In order to bind the get content, a structure must be specified as an argument.
+// The properties to get are specified with RequestQueryOption.Properties or RequestQueryOption.CustomProperties.
+func (s *ObjectServiceOp) Get(objectType ObjectType, objectID string, object interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: object}
+	if err := s.client.Get(s.path(objectType)+"/"+objectID, resource, objectQueryOption(option)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetAll gets a page of objects of the object type.
+// Use RequestQueryOption.Limit and RequestQueryOption.After to get the following pages.
+// The properties of each object are bound to a new structure of the same type as the argument.
+// RequestQueryOption.Limit is up to 100, and an error wrapping ErrInvalidLimit is returned for a larger one.
+func (s *ObjectServiceOp) GetAll(objectType ObjectType, object interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error) {
+	if err := option.validateLimit(maxListLimit); err != nil {
+		return nil, err
+	}
+	page := &pagedResponse{}
+	if err := s.client.Get(s.path(objectType), page, objectQueryOption(option)); err != nil {
+		return nil, err
+	}
+	return s.client.decodePage(page, object)
+}
+
+// Search finds objects of the object type.
+// In order to bind the get content, a structure must be specified as an argument.
+// An error is returned without making the request if the filter groups exceed the limits of HubSpot.
+func (s *ObjectServiceOp) Search(objectType ObjectType, object interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error) {
+	resource := &ResponseResourceMulti{}
+	if err := s.client.search(s.path(objectType), option.setupProperties(nil), object, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Create creates a new object of the object type.
+// In order to bind the created content, a structure must be specified as an argument.
+func (s *ObjectServiceOp) Create(objectType ObjectType, object interface{}) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: object}
+	resource := &ResponseResource{Properties: object}
+	if err := s.client.Post(s.path(objectType), req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Update updates an object of the object type.
+// In order to bind the updated content, a structure must be specified as an argument.
+func (s *ObjectServiceOp) Update(objectType ObjectType, objectID string, object interface{}) (*ResponseResource, error) {
+	req := &RequestPayload{Properties: object}
+	resource := &ResponseResource{Properties: object}
+	if err := s.client.Patch(s.path(objectType)+"/"+objectID, req, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Delete deletes an object of the object type.
+func (s *ObjectServiceOp) Delete(objectType ObjectType, objectID string) error {
+	return s.client.Delete(s.path(objectType) + "/" + objectID)
+}
+
+// objectQueryOption sets RequestQueryOption.CustomProperties to the properties to get, as there are no default properties.
+func objectQueryOption(option *RequestQueryOption) *RequestQueryOption {
+	if option != nil && len(option.Properties) != 0 {
+		return option
+	}
+	return option.setupProperties(nil)
+}
@@ -0,0 +1,119 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+type car struct {
+	Model *hubspot.HsStr `json:"model,omitempty"`
+	Year  *hubspot.HsInt `json:"year,omitempty"`
+}
+
+func TestObjectServiceOp_Get(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"car001","properties":{"model":"Roadster","year":"2008"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "car001",
+		Properties: &car{Model: hubspot.NewString("Roadster"), Year: hubspot.NewInt(2008)},
+		CreatedAt:  &createdAt,
+		UpdatedAt:  &updatedAt,
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Object.Get("2-123456", "car001", &car{}, &hubspot.RequestQueryOption{CustomProperties: []string{"model", "year"}})
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/2-123456/car001"; req.Method != http.MethodGet || req.URL.Path != want {
+		t.Errorf("Get() request mismatch: want GET %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	if want := "model,year"; req.URL.Query().Get("properties") != want {
+		t.Errorf("Get() properties mismatch: want %s got %s", want, req.URL.Query().Get("properties"))
+	}
+}
+
+func TestObjectServiceOp_GetAll(t *testing.T) {
+	pages := map[string][]byte{
+		"": []byte(`{"results":[{"id":"car001","properties":{"model":"Roadster"}}],"paging":{"next":{"after":"car002"}}}`),
+	}
+	want := &hubspot.ResponseResourceMulti{
+		Results: []hubspot.ResponseResource{{ID: "car001", Properties: &car{Model: hubspot.NewString("Roadster")}}},
+		Paging:  &hubspot.Paging{Next: &hubspot.PagingNext{After: "car002"}},
+	}
+
+	got, err := hubspot.NewMockClientWithHTTPClient(hubspot.NewMockPagesHTTPClient(pages)).CRM.Object.GetAll("p_cars", &car{}, nil)
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestObjectServiceOp_Create(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"car001","properties":{"model":"Roadster","year":"2008"}}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID:         "car001",
+		Properties: &car{Model: hubspot.NewString("Roadster"), Year: hubspot.NewInt(2008)},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Object.Create("2-123456", &car{Model: hubspot.NewString("Roadster"), Year: hubspot.NewInt(2008)})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Create() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/2-123456"; req.Method != http.MethodPost || req.URL.Path != want {
+		t.Errorf("Create() request mismatch: want POST %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	if want := `{"properties":{"model":"Roadster","year":2008}}`; string(req.Body) != want {
+		t.Errorf("Create() request body mismatch: want %s got %s", want, string(req.Body))
+	}
+}
+
+func TestObjectServiceOp_Update(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"car001","properties":{"year":"2009"}}`),
+	}
+
+	if _, err := hubspot.NewMockClient(conf).CRM.Object.Update("2-123456", "car001", &car{Year: hubspot.NewInt(2009)}); err != nil {
+		t.Fatalf("Update() unexpected error: %s", err)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/2-123456/car001"; req.Method != http.MethodPatch || req.URL.Path != want {
+		t.Errorf("Update() request mismatch: want PATCH %s got %s %s", want, req.Method, req.URL.Path)
+	}
+}
+
+func TestObjectServiceOp_Delete(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusNoContent,
+		Header: http.Header{},
+	}
+
+	if err := hubspot.NewMockClient(conf).CRM.Object.Delete(hubspot.ObjectTypeCompany, "company001"); err != nil {
+		t.Fatalf("Delete() unexpected error: %s", err)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/companies/company001"; req.Method != http.MethodDelete || req.URL.Path != want {
+		t.Errorf("Delete() request mismatch: want DELETE %s got %s %s", want, req.Method, req.URL.Path)
+	}
+}
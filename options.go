@@ -1,28 +1,266 @@
 package hubspot
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
-type Option func(c *Client)
+// Option configures the Client.
+// An error returned by an Option is returned from NewClient().
+type Option func(c *Client) error
 
 // TODO: Add WithRetryConfig
 
 func WithAPIVersion(version string) Option {
-	return func(c *Client) {
+	return func(c *Client) error {
 		c.apiVersion = version
+		return nil
 	}
 }
 
 func WithHTTPClient(client *http.Client) Option {
-	return func(c *Client) {
+	return func(c *Client) error {
 		c.HTTPClient = client
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests, so that HubSpot support can tell the traffic of the application.
+// If not set, "teltech-go-hubspot/<version>" is sent with the version of the module.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		if userAgent == "" {
+			return errors.New("the user agent is empty")
+		}
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithBaseURL sets the base URL of the HubSpot API.
+// The URL must be absolute, e.g. "https://api-eu1.hubapi.com" for EU data residency
+// or the URL of a local server for testing.
+// If not set, "https://api.hubapi.com" is used.
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithFormSubmitBaseURL sets the base URL of the form submission API used by FormService.Submit,
+// which is hosted apart from the other APIs. The URL must be absolute in the same way as WithBaseURL.
+// If not set, "https://api.hsforms.com" is used, or the URL set by WithBaseURL if it is not a HubSpot host.
+func WithFormSubmitBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.formSubmitURL = u
+		return nil
+	}
+}
+
+// parseBaseURL parses an absolute base URL, whose path is made to end with a slash.
+func parseBaseURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return nil, fmt.Errorf("invalid base url: %q is not an absolute url", rawURL)
+	}
+	// Make sure a relative path is resolved under the base path instead of replacing its last segment.
+	if u.Path != "" && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// WithPropertyValidation enables the validation of the requested properties of object requests.
+// Before a request, the properties in RequestQueryOption.Properties and RequestSearchOption.Properties
+// are checked against the properties defined in HubSpot, and an error wrapping ErrUnknownProperty is returned
+// for the unknown ones instead of HubSpot silently ignoring them.
+// The defined properties are fetched with PropertyService and cached per object type for ttl, 10 minutes if ttl is not positive.
+func WithPropertyValidation(ttl time.Duration) Option {
+	return func(c *Client) error {
+		c.propertySchema = newPropertySchema(ttl)
+		return nil
+	}
+}
+
+// WithTimeout sets a timeout to each request, covering the time to send it and read its response.
+// It applies to every request individually, so a long-lived Client is not affected.
+// If the context of a request already has a deadline, the sooner of the two is used.
+// There is no timeout if d is not positive, which is the default.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		c.timeout = d
+		return nil
+	}
+}
+
+// WithMetrics sets the Metrics observing the latency and status of each request and the retries.
+// Nothing is measured if it is not set.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) error {
+		c.metrics = m
+		return nil
+	}
+}
+
+// WithTracerProvider enables tracing with OpenTelemetry, wrapping each request in a client span.
+// The span is a child of the span in the context of the request, if any, and has the method, the templated path,
+// the status code and the HubSpot correlation ID as attributes.
+// Nothing is traced if it is not set.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) error {
+		c.tracer = tp.Tracer(tracerName)
+		return nil
+	}
+}
+
+// WithPropertyTransformer applies the PropertyTransformer to the values of the properties of the given names,
+// e.g. to encrypt personal data stored in custom properties.
+// The values are encoded in the "properties" of the request bodies and decoded in the "properties" and
+// "propertiesWithHistory" of the responses, so the structures bound to the properties have the plain values.
+// NOTE: The values in the filters of a search are sent as they are, so a search on a transformed property
+// needs the encoded value, which requires a deterministic Encode.
+func WithPropertyTransformer(t PropertyTransformer, names ...string) Option {
+	return func(c *Client) error {
+		if t == nil {
+			return errors.New("property transformer is nil")
+		}
+		if len(names) == 0 {
+			return errors.New("property transformer requires at least one property name")
+		}
+		c.propertyTransform = newPropertyTransform(t, names)
+		return nil
+	}
+}
+
+// WithRateLimit throttles the requests to at most perTenSeconds per 10 seconds, the window of HubSpot rate limits,
+// so that bulk operations stay under the limit instead of being rejected with 429 Too Many Requests.
+// A request waits for its turn until its context is done, in which case the error of the context is returned.
+// The allowance is also lowered to the remaining requests HubSpot reports in the X-HubSpot-RateLimit-Remaining header,
+// e.g. when other clients share the limit of the app.
+func WithRateLimit(perTenSeconds int) Option {
+	return func(c *Client) error {
+		if perTenSeconds <= 0 {
+			return fmt.Errorf("invalid rate limit: %d requests per 10 seconds", perTenSeconds)
+		}
+		c.rateLimiter = newRateLimiter(perTenSeconds, rateLimitInterval/time.Duration(perTenSeconds))
+		return nil
+	}
+}
+
+// WithRateLimitPerSecond throttles the requests to perSecond per second on average, allowing bursts of up to burst requests,
+// in the same way as WithRateLimit. A zero perSecond defaults to 10, the standard limit of HubSpot, and a zero burst
+// to perSecond rounded up, so WithRateLimitPerSecond(0, 0) smooths the bursts of a sync job under the standard limit.
+func WithRateLimitPerSecond(perSecond float64, burst int) Option {
+	return func(c *Client) error {
+		if perSecond == 0 {
+			perSecond = defaultRateLimitPerSecond
+		}
+		if perSecond < 0 || math.IsInf(perSecond, 0) || math.IsNaN(perSecond) {
+			return fmt.Errorf("invalid rate limit: %v requests per second", perSecond)
+		}
+		if burst == 0 {
+			burst = int(math.Ceil(perSecond))
+		}
+		if burst < 0 {
+			return fmt.Errorf("invalid rate limit burst: %d", burst)
+		}
+		c.rateLimiter = newRateLimiter(burst, time.Duration(float64(time.Second)/perSecond))
+		return nil
+	}
+}
+
+// WithBatchArchiveLimit sets the number of objects a batch archive is allowed, 10 by default, so that a bug does not
+// archive the whole portal. Archiving more objects requires BatchArchiveOption.Force, and an error wrapping
+// ErrArchiveNotConfirmed is returned otherwise without making any request.
+func WithBatchArchiveLimit(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("invalid batch archive limit: %d", n)
+		}
+		c.archiveLimit = n
+		return nil
+	}
+}
+
+// WithWriteSource sets the objectWriteTraceId of each input of the batch writes, such as CompanyService BatchUpsert
+// and BatchUpdate, to the source followed by the ID of the input, e.g. "billing-sync:ext-1".
+// HubSpot returns it in ErrContext.ObjectWriteTraceID of the errors of the input, which tells which system's write failed,
+// but does not store it on the object. The single object writes take no objectWriteTraceId.
+func WithWriteSource(source string) Option {
+	return func(c *Client) error {
+		if source == "" {
+			return errors.New("empty write source")
+		}
+		c.writeSource = source
+		return nil
+	}
+}
+
+// WithDomainNormalization normalizes the domain of the companies on CompanyService Create and Update with NormalizeDomain,
+// e.g. "https://www.Acme.com/" is sent as "acme.com", so that HubSpot does not create duplicates for different forms of a domain.
+// The domain is sent as it is if it is not set.
+func WithDomainNormalization() Option {
+	return func(c *Client) error {
+		c.normalizeDomain = true
+		return nil
+	}
+}
+
+// WithDryRun stops the writes before they are sent, e.g. to check the payloads of a migration without changing any data.
+// A write returns a *DryRunError holding the request that would have been sent, matching ErrDryRun with errors.Is.
+// The reads, including the search and batch read requests, are still sent.
+// If property validation is enabled with WithPropertyValidation, the properties set in the body of a write to an object
+// endpoint are also checked, and an error wrapping ErrUnknownProperty is returned for the unknown ones.
+func WithDryRun() Option {
+	return func(c *Client) error {
+		c.dryRun = true
+		return nil
+	}
+}
+
+// WithStrictDecoding rejects the responses having a field that the structure they are decoded into has no field for,
+// in the same way as json.Decoder.DisallowUnknownFields, so that changes of the payloads of HubSpot are noticed,
+// e.g. in integration tests. An error wrapping ErrUnknownField with the paths of the unknown fields is returned.
+// The properties bound to ResponseResource.Properties are also checked, including those of the search,
+// batch read and list results. It is disabled by default, as HubSpot adds fields to its responses over time.
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Client) error {
+		c.strictDecoding = strict
+		return nil
 	}
 }
 
-func WithBaseURL(url *url.URL) Option {
-	return func(c *Client) {
-		c.baseURL = url
+// WithResponseObserver sets a function called with the metadata of each response received from HubSpot,
+// including the error responses, e.g. to log the request ID and the status for HubSpot support tickets.
+// ctx is the context of the request, carrying its span if tracing is enabled by WithTracerProvider.
+// The function is called synchronously from the goroutine making the request, so it should return quickly,
+// and must be safe for concurrent use when requests are made concurrently.
+func WithResponseObserver(fn func(ctx context.Context, meta *ResponseMeta)) Option {
+	return func(c *Client) error {
+		if fn == nil {
+			return errors.New("response observer is nil")
+		}
+		c.responseObserver = fn
+		return nil
 	}
 }
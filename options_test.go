@@ -1,6 +1,8 @@
 package hubspot_test
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
@@ -26,10 +28,128 @@ func TestWithHTTPClient(t *testing.T) {
 	}
 }
 
+func TestWithUserAgent(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []hubspot.Option
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Default User-Agent",
+			want: "teltech-go-hubspot/devel",
+		},
+		{
+			name: "Custom User-Agent",
+			opts: []hubspot.Option{hubspot.WithUserAgent("billing-sync/2.0")},
+			want: "billing-sync/2.0",
+		},
+		{
+			name:    "Failed with empty User-Agent",
+			opts:    []hubspot.Option{hubspot.WithUserAgent("")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: []byte(`{}`)}
+			opts := append([]hubspot.Option{hubspot.WithHTTPClient(hubspot.NewMockHTTPClient(conf))}, tt.opts...)
+			c, err := hubspot.NewClient(hubspot.SetAPIKey("key"), opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error mismatch: want error %t got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if err := c.CreateAndDo(http.MethodGet, "crm/v3/objects/companies", nil, nil, nil); err != nil {
+				t.Fatalf("CreateAndDo() unexpected error: %s", err)
+			}
+			if got := conf.Requests[0].Header.Get("User-Agent"); got != tt.want {
+				t.Errorf("User-Agent mismatch: want %s got %s", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestWithBaseURL(t *testing.T) {
-	want := &url.URL{Scheme: "http", Host: "example.com"}
-	c, _ := hubspot.NewClient(hubspot.SetAPIKey("key"), hubspot.WithBaseURL(want))
-	if diff := cmp.Diff(want, c.ExportGetBaseURL()); diff != "" {
-		t.Errorf("WithBaseURL() result mismatch: (-want +got):%s", diff)
+	tests := []struct {
+		name    string
+		baseURL string
+		want    *url.URL
+		wantErr bool
+	}{
+		{
+			name:    "Success with host only",
+			baseURL: "http://example.com",
+			want:    &url.URL{Scheme: "http", Host: "example.com"},
+		},
+		{
+			name:    "Success with path",
+			baseURL: "http://127.0.0.1:8080/hubspot",
+			want:    &url.URL{Scheme: "http", Host: "127.0.0.1:8080", Path: "/hubspot/"},
+		},
+		{
+			name:    "Failed with relative url",
+			baseURL: "/hubspot",
+			wantErr: true,
+		},
+		{
+			name:    "Failed with invalid url",
+			baseURL: "http://[::1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := hubspot.NewClient(hubspot.SetAPIKey("key"), hubspot.WithBaseURL(tt.baseURL))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithBaseURL() error mismatch: wantErr %v got %s", tt.wantErr, err)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, c.ExportGetBaseURL()); diff != "" {
+				t.Errorf("WithBaseURL() result mismatch: (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+type deadlineRoundTripper struct {
+	deadlines []time.Time
+}
+
+func (rt *deadlineRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	deadline, _ := req.Context().Deadline()
+	rt.deadlines = append(rt.deadlines, deadline)
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestWithTimeout(t *testing.T) {
+	rt := &deadlineRoundTripper{}
+	c := hubspot.NewMockClientWithHTTPClient(&http.Client{Transport: rt})
+	if err := hubspot.WithTimeout(10 * time.Millisecond)(c); err != nil {
+		t.Fatalf("WithTimeout() unexpected error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		start := time.Now()
+		err := c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("CreateAndDoWithContext() error mismatch: want context.DeadlineExceeded got %v", err)
+		}
+		if d := rt.deadlines[i].Sub(start); d <= 0 || d > time.Second {
+			t.Errorf("CreateAndDoWithContext() deadline mismatch: want about 10ms after the request got %s", d)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	_ = c.CreateAndDoWithContext(ctx, http.MethodGet, "crm/v3/objects/companies", nil, nil, nil)
+	if got := rt.deadlines[2]; !got.Equal(want) {
+		t.Errorf("CreateAndDoWithContext() deadline mismatch: want the sooner context deadline %s got %s", want, got)
 	}
 }
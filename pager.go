@@ -0,0 +1,268 @@
+package hubspot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	// searchResultLimit is the maximum number of results HubSpot search returns for a query across all pages.
+	searchResultLimit = 10000
+)
+
+// CompanyPager iterates over companies page by page, following the Paging.Next.After cursor.
+// e.g.
+//
+//	for pager.HasNext() {
+//		companies, err := pager.Next(ctx)
+//	}
+type CompanyPager struct {
+	// fetch gets the results of the next page, with the properties bound to *Company,
+	// and reports whether there are more pages after it.
+	fetch func(ctx context.Context) ([]ResponseResource, bool, error)
+
+	done bool
+}
+
+// pagedResponse is a list response whose results are decoded one by one.
+type pagedResponse struct {
+	Total   int               `json:"total,omitempty"`
+	Results []json.RawMessage `json:"results"`
+	Paging  *Paging           `json:"paging,omitempty"`
+}
+
+// decodePage decodes the results of a page.
+// The properties of each result are bound to a new structure of the same type as properties, which must be a pointer.
+// If properties is not a pointer, they are decoded as a map.
+func (c *Client) decodePage(page *pagedResponse, properties interface{}) (*ResponseResourceMulti, error) {
+	resource := &ResponseResourceMulti{Results: make([]ResponseResource, 0, len(page.Results)), Paging: page.Paging, Total: page.Total}
+	for _, raw := range page.Results {
+		result := ResponseResource{Properties: newPropertiesOf(properties)}
+		if err := c.unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		resource.Results = append(resource.Results, result)
+	}
+	return resource, nil
+}
+
+// newPropertiesOf returns a new structure of the same type as properties if it is a pointer, or nil otherwise.
+func newPropertiesOf(properties interface{}) interface{} {
+	t := reflect.TypeOf(properties)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil
+	}
+	return reflect.New(t.Elem()).Interface()
+}
+
+// newCompanyListPager returns a pager over the companies list endpoint.
+func newCompanyListPager(s *CompanyServiceOp, option *RequestQueryOption) *CompanyPager {
+	opts := &RequestQueryOption{}
+	if option != nil {
+		*opts = *option
+	}
+	if len(opts.Properties) == 0 {
+		opts = opts.setupProperties(defaultCompanyFields)
+	}
+	path := s.companyPath
+	return &CompanyPager{
+		fetch: func(ctx context.Context) ([]ResponseResource, bool, error) {
+			if err := opts.validateLimit(maxListLimit); err != nil {
+				return nil, false, err
+			}
+			page := &pagedResponse{}
+			if err := s.client.CreateAndDoWithContext(ctx, http.MethodGet, path, nil, opts, page); err != nil {
+				return nil, false, err
+			}
+			resource, err := s.client.decodePage(page, &Company{})
+			if err != nil {
+				return nil, false, err
+			}
+			next, nextOpts, ok, err := s.client.nextPage(page.Paging, path, opts)
+			if err != nil {
+				return nil, false, err
+			}
+			path, opts = next, nextOpts
+			return resource.Results, ok, nil
+		},
+	}
+}
+
+// modifiedSearch is the state of a search over the objects modified since a watermark.
+type modifiedSearch struct {
+	since time.Time
+	after string
+	// skipIDs are the IDs of the objects modified exactly at since that have already been returned.
+	skipIDs map[string]bool
+
+	// lastModified and lastModifiedIDs track the latest modification time returned in the current query,
+	// and the IDs of the objects modified at that time.
+	lastModified    time.Time
+	lastModifiedIDs map[string]bool
+}
+
+// newCompanyModifiedPager returns a pager over the companies modified at or after since, in ascending order of modification.
+// HubSpot search stops returning results after 10,000 of them,
+// so when the cursor reaches the limit a new query is started from the latest modification time returned so far.
+func newCompanyModifiedPager(s *CompanyServiceOp, since time.Time, option *RequestQueryOption) *CompanyPager {
+	opts := &RequestQueryOption{}
+	if option != nil {
+		*opts = *option
+	}
+	if len(opts.Properties) == 0 {
+		opts = opts.setupProperties(defaultCompanyFields)
+	}
+	// A limit above maxSearchLimit is rejected with ErrInvalidLimit by the validation of the search.
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = maxSearchLimit
+	}
+	state := &modifiedSearch{since: since, lastModified: since, lastModifiedIDs: map[string]bool{}}
+
+	return &CompanyPager{
+		fetch: func(ctx context.Context) ([]ResponseResource, bool, error) {
+			req := (&RequestSearchOption{Properties: opts.Properties, Limit: limit, After: state.after}).
+				UpdatedAfter(state.since).
+				AddSort(searchPropertyLastModifiedDate, SortDirectionAscending)
+			page := &ResponseResourceMulti{}
+			if err := s.client.searchWithContext(ctx, s.companyPath, req, &Company{}, page); err != nil {
+				return nil, false, err
+			}
+			results := state.filter(page.Results)
+
+			after, ok, err := s.client.nextCursor(page.Paging)
+			if err != nil || !ok {
+				return results, false, err
+			}
+			if offset, err := strconv.Atoi(after); err == nil && offset+limit > searchResultLimit {
+				if err := state.advance(); err != nil {
+					return nil, false, err
+				}
+				return results, true, nil
+			}
+			state.after = after
+			return results, true, nil
+		},
+	}
+}
+
+// filter drops the results already returned by the previous query, and tracks the latest modification time.
+func (m *modifiedSearch) filter(page []ResponseResource) []ResponseResource {
+	results := make([]ResponseResource, 0, len(page))
+	for _, r := range page {
+		modified := r.UpdatedAt.Time()
+		if m.skipIDs[r.ID] && modified.Equal(m.since) {
+			continue
+		}
+		results = append(results, r)
+
+		switch {
+		case modified.After(m.lastModified):
+			m.lastModified = modified
+			m.lastModifiedIDs = map[string]bool{r.ID: true}
+		case modified.Equal(m.lastModified):
+			m.lastModifiedIDs[r.ID] = true
+		}
+	}
+	return results
+}
+
+// advance starts a new query from the latest modification time returned so far.
+func (m *modifiedSearch) advance() error {
+	if !m.lastModified.After(m.since) {
+		return errors.New("unable to advance the search: more than 10000 objects were modified at the same time")
+	}
+	m.since = m.lastModified
+	m.skipIDs = m.lastModifiedIDs
+	m.after = ""
+	return nil
+}
+
+// newCompanyDeepSearchPager returns a pager over the companies matching the search, in ascending order of ID.
+// Each page is a new query narrowed to the IDs greater than the last one returned, so the 10,000 results cap is never reached.
+// The option is validated once, before the first request, and its error is returned by the first Next.
+func newCompanyDeepSearchPager(s *CompanyServiceOp, option *RequestSearchOption) *CompanyPager {
+	opts := option.setupProperties(defaultCompanyFields)
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = maxSearchLimit
+	}
+	invalid := validateDeepSearch(opts)
+	var lastID string
+
+	return &CompanyPager{
+		fetch: func(ctx context.Context) ([]ResponseResource, bool, error) {
+			if invalid != nil {
+				return nil, false, invalid
+			}
+			req := opts.clone()
+			req.Limit = limit
+			req.After = ""
+			req.Sorts = []Sort{{PropertyName: searchPropertyObjectID, Direction: SortDirectionAscending}}
+			if lastID != "" {
+				// ExpectResults only applies to the first page, an empty page after it is the end of the results.
+				req.ExpectResults = false
+				req.andFilter(Filter{PropertyName: searchPropertyObjectID, Operator: FilterOperatorGreaterThan, Value: lastID})
+			}
+			page := &ResponseResourceMulti{}
+			if err := s.client.searchWithContext(ctx, s.companyPath, req, &Company{}, page); err != nil {
+				return nil, false, err
+			}
+			if len(page.Results) == 0 {
+				return page.Results, false, nil
+			}
+			lastID = page.Results[len(page.Results)-1].ID
+			_, more, err := s.client.nextCursor(page.Paging)
+			return page.Results, more, err
+		},
+	}
+}
+
+// validateDeepSearch checks the option of a deep search as it is sent from the second page on, with the filter on
+// the ID added to every filter group, so that an option filling a filter group fails before any result is returned.
+func validateDeepSearch(opts *RequestSearchOption) error {
+	for _, sort := range opts.Sorts {
+		if sort.PropertyName != searchPropertyObjectID || sort.Direction != SortDirectionAscending {
+			return fmt.Errorf("unable to search deep: sorted by %s %s, only %s %s is allowed",
+				sort.PropertyName, sort.Direction, searchPropertyObjectID, SortDirectionAscending)
+		}
+	}
+	req := opts.clone()
+	req.andFilter(Filter{PropertyName: searchPropertyObjectID, Operator: FilterOperatorGreaterThan, Value: "0"})
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("unable to search deep, one filter of each filter group is taken by the ID: %w", err)
+	}
+	return nil
+}
+
+// HasNext reports whether there may be another page.
+func (p *CompanyPager) HasNext() bool {
+	return !p.done
+}
+
+// Next gets the next page of companies.
+// The properties of each company are bound to *Company.
+// After the last page, it returns an empty slice and HasNext reports false.
+func (p *CompanyPager) Next(ctx context.Context) ([]*ResponseResource, error) {
+	if p.done {
+		return []*ResponseResource{}, nil
+	}
+	page, more, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ResponseResource, 0, len(page))
+	for i := range page {
+		results = append(results, &page[i])
+	}
+
+	p.done = !more
+	return results, nil
+}
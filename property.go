@@ -0,0 +1,210 @@
+package hubspot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	propertyBasePath = "properties"
+)
+
+// PropertyService is an interface of property endpoints of the HubSpot API.
+// Properties are the fields of CRM objects, including the custom properties defined in the account.
+// Reference: https://developers.hubspot.com/docs/api/crm/properties
+type PropertyService interface {
+	Get(objectType ObjectType, propertyName string) (*Property, error)
+	GetAll(objectType ObjectType) ([]*Property, error)
+}
+
+// PropertyServiceOp handles communication with the property related methods of the HubSpot API.
+type PropertyServiceOp struct {
+	propertyPath string
+	client       *Client
+}
+
+var _ PropertyService = (*PropertyServiceOp)(nil)
+
+// Property is the definition of a property of an object type.
+type Property struct {
+	Name           string           `json:"name,omitempty"`
+	Label          string           `json:"label,omitempty"`
+	Type           string           `json:"type,omitempty"`
+	FieldType      string           `json:"fieldType,omitempty"`
+	GroupName      string           `json:"groupName,omitempty"`
+	Description    string           `json:"description,omitempty"`
+	Options        []PropertyOption `json:"options,omitempty"`
+	Calculated     bool             `json:"calculated,omitempty"`
+	HasUniqueValue bool             `json:"hasUniqueValue,omitempty"`
+	Hidden         bool             `json:"hidden,omitempty"`
+	CreatedAt      *HsTime          `json:"createdAt,omitempty"`
+	UpdatedAt      *HsTime          `json:"updatedAt,omitempty"`
+}
+
+// PropertyOption is an option of an enumeration property.
+type PropertyOption struct {
+	Label        string `json:"label,omitempty"`
+	Value        string `json:"value,omitempty"`
+	DisplayOrder int    `json:"displayOrder,omitempty"`
+	Hidden       bool   `json:"hidden,omitempty"`
+}
+
+type propertyList struct {
+	Results []*Property `json:"results"`
+}
+
+// Get gets the definition of a property.
+// The objectType is the object type of the property such as ObjectTypeCompany.
+func (s *PropertyServiceOp) Get(objectType ObjectType, propertyName string) (*Property, error) {
+	resource := &Property{}
+	if err := s.client.Get(s.propertyPath+"/"+string(objectType)+"/"+propertyName, resource, nil); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetAll gets the definitions of all properties of an object type.
+// The objectType is the object type of the properties such as ObjectTypeCompany.
+func (s *PropertyServiceOp) GetAll(objectType ObjectType) ([]*Property, error) {
+	resource := &propertyList{}
+	if err := s.client.Get(s.propertyPath+"/"+string(objectType), resource, nil); err != nil {
+		return nil, err
+	}
+	return resource.Results, nil
+}
+
+// defaultPropertySchemaTTL is how long the properties of an object type are cached when the TTL is not specified.
+const defaultPropertySchemaTTL = 10 * time.Minute
+
+// defaultFieldsByObjectType are the properties requested by default for each object type.
+// They are not validated, since they are requested regardless of the option.
+var defaultFieldsByObjectType = map[ObjectType][]string{
+	ObjectTypeCompany:                      defaultCompanyFields,
+	ObjectTypeContact:                      defaultContactFields,
+	ObjectTypeDeal:                         defaultDealFields,
+	ObjectType(emailBasePath):              defaultEmailFields,
+	ObjectType(meetingBasePath):            defaultMeetingFields,
+	ObjectType(callBasePath):               defaultCallFields,
+	ObjectType(feedbackSubmissionBasePath): defaultFeedbackSubmissionFields,
+}
+
+// propertySchema validates the requested properties against the property definitions of the object type.
+// The property names are cached per object type until the TTL expires.
+type propertySchema struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[ObjectType]*propertySchemaEntry
+}
+
+type propertySchemaEntry struct {
+	names     map[string]bool
+	expiresAt time.Time
+}
+
+func newPropertySchema(ttl time.Duration) *propertySchema {
+	if ttl <= 0 {
+		ttl = defaultPropertySchemaTTL
+	}
+	return &propertySchema{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[ObjectType]*propertySchemaEntry{},
+	}
+}
+
+// validate checks the properties requested in the option or the search request body of an object request.
+// Requests to other endpoints are not validated.
+func (s *propertySchema) validate(c *Client, relPath string, option, data interface{}) error {
+	objectType, ok := objectTypeFromPath(c, relPath)
+	if !ok {
+		return nil
+	}
+
+	var requested []string
+	if o, ok := option.(*RequestQueryOption); ok && o != nil {
+		requested = append(requested, o.Properties...)
+	}
+	if o, ok := data.(*RequestSearchOption); ok && o != nil {
+		requested = append(requested, o.Properties...)
+	}
+	return s.check(c, objectType, requested)
+}
+
+// validatePayload checks the properties set in the body of a write request to an object endpoint.
+// Requests to other endpoints are not validated.
+func (s *propertySchema) validatePayload(c *Client, relPath string, data interface{}) error {
+	objectType, ok := objectTypeFromPath(c, relPath)
+	if !ok {
+		return nil
+	}
+	requested, err := payloadProperties(data)
+	if err != nil {
+		return err
+	}
+	return s.check(c, objectType, requested)
+}
+
+// check returns an error wrapping ErrUnknownProperty if any of the requested properties is not defined for the object type.
+func (s *propertySchema) check(c *Client, objectType ObjectType, requested []string) error {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	names, err := s.propertyNames(c, objectType)
+	if err != nil {
+		return fmt.Errorf("unable to get the properties of %s: %w", objectType, err)
+	}
+	defaults := map[string]bool{}
+	for _, name := range defaultFieldsByObjectType[objectType] {
+		defaults[name] = true
+	}
+
+	var unknown []string
+	for _, name := range requested {
+		if !names[name] && !defaults[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) != 0 {
+		return fmt.Errorf("%w of %s: %s", ErrUnknownProperty, objectType, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// propertyNames returns the names of the properties of the object type, fetching them if the cache is expired.
+func (s *propertySchema) propertyNames(c *Client, objectType ObjectType) (map[string]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[objectType]; ok && s.now().Before(entry.expiresAt) {
+		return entry.names, nil
+	}
+
+	properties, err := c.CRM.Property.GetAll(objectType)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		names[p.Name] = true
+	}
+	s.entries[objectType] = &propertySchemaEntry{names: names, expiresAt: s.now().Add(s.ttl)}
+	return names, nil
+}
+
+// objectTypeFromPath returns the object type of a path under crm/v3/objects.
+func objectTypeFromPath(c *Client, relPath string) (ObjectType, bool) {
+	prefix := fmt.Sprintf("%s/%s/%s/", crmBasePath, c.apiVersion, objectsBasePath)
+	if !strings.HasPrefix(relPath, prefix) {
+		return "", false
+	}
+	objectType := strings.TrimPrefix(relPath, prefix)
+	if i := strings.Index(objectType, "/"); i >= 0 {
+		objectType = objectType[:i]
+	}
+	return ObjectType(objectType), objectType != ""
+}
@@ -0,0 +1,94 @@
+package hubspot_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+const companyPropertiesBody = `{"results":[{"name":"name","label":"Name","type":"string","fieldType":"text","groupName":"companyinformation"},{"name":"trial_status","label":"Trial status","type":"enumeration","fieldType":"select","groupName":"companyinformation","options":[{"label":"Active","value":"active","displayOrder":1}]}]}`
+
+func TestPropertyServiceOp_GetAll(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(companyPropertiesBody),
+	}
+	want := []*hubspot.Property{
+		{Name: "name", Label: "Name", Type: "string", FieldType: "text", GroupName: "companyinformation"},
+		{
+			Name:      "trial_status",
+			Label:     "Trial status",
+			Type:      "enumeration",
+			FieldType: "select",
+			GroupName: "companyinformation",
+			Options:   []hubspot.PropertyOption{{Label: "Active", Value: "active", DisplayOrder: 1}},
+		},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Property.GetAll(hubspot.ObjectTypeCompany)
+	if err != nil {
+		t.Fatalf("GetAll() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetAll() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/properties/companies"; conf.Requests[0].URL.Path != want {
+		t.Errorf("GetAll() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestWithPropertyValidation(t *testing.T) {
+	var propertyRequests, companyRequests int
+	httpClient := &http.Client{
+		Transport: hubspot.RoundTripFunc(func(req *http.Request) *http.Response {
+			body := `{"id":"company001","properties":{"name":"Acme"}}`
+			if strings.HasPrefix(req.URL.Path, "/crm/v3/properties/") {
+				propertyRequests++
+				body = companyPropertiesBody
+			} else {
+				companyRequests++
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+				Header:     http.Header{},
+			}
+		}),
+	}
+	c := hubspot.NewMockClientWithHTTPClient(httpClient)
+	if err := hubspot.WithPropertyValidation(time.Minute)(c); err != nil {
+		t.Fatalf("WithPropertyValidation() unexpected error: %s", err)
+	}
+
+	if _, err := c.CRM.Company.Get("company001", &hubspot.Company{}, &hubspot.RequestQueryOption{CustomProperties: []string{"trial_status"}}); err != nil {
+		t.Errorf("Get() unexpected error: %s", err)
+	}
+
+	_, err := c.CRM.Company.Get("company001", &hubspot.Company{}, &hubspot.RequestQueryOption{CustomProperties: []string{"trial_statuss"}})
+	if !errors.Is(err, hubspot.ErrUnknownProperty) {
+		t.Errorf("Get() error mismatch: want ErrUnknownProperty got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "trial_statuss") {
+		t.Errorf("Get() error mismatch: want the unknown property in %q", err.Error())
+	}
+
+	_, err = c.CRM.Company.Search(&hubspot.Company{}, &hubspot.RequestSearchOption{Properties: []string{"name", "nmae"}})
+	if !errors.Is(err, hubspot.ErrUnknownProperty) {
+		t.Errorf("Search() error mismatch: want ErrUnknownProperty got %v", err)
+	}
+
+	if propertyRequests != 1 {
+		t.Errorf("property requests mismatch: want 1 cached request got %d", propertyRequests)
+	}
+	if companyRequests != 1 {
+		t.Errorf("company requests mismatch: want 1 got %d", companyRequests)
+	}
+}
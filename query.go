@@ -1,44 +1,321 @@
 package hubspot
 
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
 // RequestQueryOption is a set of options to be specified in the query when making a Get request.
-// RequestQueryOption.Properties will be overwritten internally, so do not specify it.
+// RequestQueryOption.Properties will be overwritten internally, so do not specify it unless NoDefaultProperties is set.
 // If you want to get the custom fields as well, specify the field names in RequestQueryOption.CustomProperties.
 // Items with no value set will be ignored.
 type RequestQueryOption struct {
 	Properties           []string `url:"properties,comma,omitempty"`
 	CustomProperties     []string `url:"-"`
-	Associations         []string `url:"associations,comma,omitempty"`
+	Associations         []string `url:"associations,comma,omitempty"`   // Returned inline in ResponseResource.Associations
 	PaginateAssociations bool     `url:"paginateAssociations,omitempty"` // HubSpot defaults false
 	Archived             bool     `url:"archived,omitempty"`             // HubSpot defaults false
 	IDProperty           string   `url:"idProperty,omitempty"`
+	// NoDefaultProperties requests only Properties and CustomProperties, without the default properties of the object,
+	// to keep the response small. If both are empty, HubSpot returns its own default properties.
+	NoDefaultProperties bool `url:"-"`
+	// PropertiesWithHistory are the properties whose past values are returned in ResponseResource.PropertiesWithHistory.
+	// HubSpot limits the number of them in a request, so Get splits them into several requests when needed.
+	PropertiesWithHistory []string `url:"propertiesWithHistory,comma,omitempty"`
+	Limit                 int      `url:"limit,omitempty"` // HubSpot defaults 10, up to 100 for the list endpoints
+	After                 string   `url:"after,omitempty"` // Cursor of the page to get, taken from Paging.Next.After
+}
+
+// maxListLimit is the maximum RequestQueryOption.Limit HubSpot accepts in the list endpoints of CRM objects.
+const maxListLimit = 100
+
+// validateLimit checks that RequestQueryOption.Limit is not more than the maximum of the endpoint,
+// so that a clear error is returned instead of the 400 Bad Request of HubSpot.
+func (o *RequestQueryOption) validateLimit(max int) error {
+	if o == nil || o.Limit <= max {
+		return nil
+	}
+	return fmt.Errorf("%w: %d, up to %d is allowed", ErrInvalidLimit, o.Limit, max)
 }
 
 // setupProperties sets the property to get.
-// RequestQueryOption.Properties will be overwritten.
+// RequestQueryOption.Properties will be overwritten, unless NoDefaultProperties is set
+// and it is only followed by the custom properties.
 // If RequestQueryOption is nil, only the default properties will be set.
 func (o *RequestQueryOption) setupProperties(defaultFields []string) *RequestQueryOption {
 	opts := RequestQueryOption{}
 	if o != nil {
 		opts = *o
 	}
-	opts.Properties = append(defaultFields, opts.CustomProperties...)
+	if opts.NoDefaultProperties {
+		opts.Properties = mergeProperties(opts.Properties, opts.CustomProperties)
+		return &opts
+	}
+	opts.Properties = mergeProperties(defaultFields, opts.CustomProperties)
 	return &opts
 }
 
+// mergeProperties returns a new slice of the default properties followed by the custom properties.
+func mergeProperties(defaultFields, customFields []string) []string {
+	properties := make([]string, 0, len(defaultFields)+len(customFields))
+	properties = append(properties, defaultFields...)
+	return append(properties, customFields...)
+}
+
+// Filter operators of Filter.Operator.
+// IN and NOT_IN compare with Filter.Values, BETWEEN with Filter.Value and Filter.HighValue,
+// HAS_PROPERTY and NOT_HAS_PROPERTY take no value, and the others compare with Filter.Value.
+// Reference: https://developers.hubspot.com/docs/api/crm/search#filter-search-results
 const (
-	FilterOperatorEqual = "EQ"
+	FilterOperatorEqual              = "EQ"
+	FilterOperatorNotEqual           = "NEQ"
+	FilterOperatorLessThan           = "LT"
+	FilterOperatorLessThanOrEqual    = "LTE"
+	FilterOperatorGreaterThan        = "GT"
+	FilterOperatorGreaterThanOrEqual = "GTE"
+	FilterOperatorBetween            = "BETWEEN"
+	FilterOperatorIn                 = "IN"
+	FilterOperatorNotIn              = "NOT_IN"
+	FilterOperatorHasProperty        = "HAS_PROPERTY"
+	FilterOperatorNotHasProperty     = "NOT_HAS_PROPERTY"
+	FilterOperatorContainsToken      = "CONTAINS_TOKEN"
+	FilterOperatorNotContainsToken   = "NOT_CONTAINS_TOKEN"
 )
 
+const (
+	searchPropertyLastModifiedDate = "hs_lastmodifieddate"
+	searchPropertyCreateDate       = "hs_createdate"
+	searchPropertyObjectID         = "hs_object_id"
+)
+
+const (
+	// maxSearchFilterGroups is the maximum number of filter groups HubSpot accepts in a search request.
+	maxSearchFilterGroups = 5
+	// maxSearchFiltersPerGroup is the maximum number of filters HubSpot accepts in a filter group.
+	maxSearchFiltersPerGroup = 6
+	// maxSearchLimit is the maximum RequestSearchOption.Limit HubSpot accepts, i.e. the most results of a search page.
+	// It is also the page size of the search pagers when the limit is not set.
+	maxSearchLimit = 200
+)
+
+// RequestSearchOption is the request body of a Search request.
+// NOTE: HubSpot indexes the objects for search a few seconds after they are created or updated,
+// so a search right after a write may not find them. See RequestSearchOption.ExpectResults.
+// The filter groups are OR'd together and the filters within a group are AND'd,
+// i.e. an object matches if it matches all filters of at least one group.
+// HubSpot accepts up to 5 filter groups of up to 6 filters each.
+// If RequestSearchOption.Properties is empty, the default properties and RequestSearchOption.CustomProperties are requested,
+// in the same way as RequestQueryOption.
 type RequestSearchOption struct {
-	FilterGroups []FilterGroup `json:"filterGroups,omitempty"`
+	Query            string        `json:"query,omitempty"` // Free-text search across the default searchable properties
+	FilterGroups     []FilterGroup `json:"filterGroups,omitempty"`
+	Sorts            []Sort        `json:"sorts,omitempty"`
+	Properties       []string      `json:"properties,omitempty"`
+	CustomProperties []string      `json:"-"`
+	Limit            int           `json:"limit,omitempty"` // HubSpot defaults 10, up to 200
+	After            string        `json:"after,omitempty"` // Cursor of the page to get, taken from Paging.Next.After
+	// ExpectResults retries the search with backoff while it returns no result, since HubSpot indexes the objects
+	// for search a few seconds after they are written, e.g. to find an object right after Create in a test.
+	// An error wrapping ErrSearchNotIndexed is returned if there is still no result after the retries.
+	// Only the first page is retried, and SearchDeep ignores it.
+	ExpectResults bool `json:"-"`
 }
 
+// setupProperties sets the property to get.
+// If RequestSearchOption.Properties is already specified, it will be used as it is.
+// If RequestSearchOption is nil, only the default properties will be set.
+func (o *RequestSearchOption) setupProperties(defaultFields []string) *RequestSearchOption {
+	opts := RequestSearchOption{}
+	if o != nil {
+		opts = *o
+	}
+	if len(opts.Properties) == 0 {
+		opts.Properties = mergeProperties(defaultFields, opts.CustomProperties)
+	}
+	return &opts
+}
+
+// Validate checks that the filter groups and the limit are within the limits of HubSpot, so that the request is not rejected.
+// An error wrapping ErrInvalidLimit is returned if RequestSearchOption.Limit is more than 200,
+// and one wrapping ErrInvalidSortDirection if a sort direction is not one of the SortDirection constants.
+func (o *RequestSearchOption) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Limit > maxSearchLimit {
+		return fmt.Errorf("%w: %d, up to %d is allowed", ErrInvalidLimit, o.Limit, maxSearchLimit)
+	}
+	if len(o.FilterGroups) > maxSearchFilterGroups {
+		return fmt.Errorf("too many filter groups: %d, up to %d filter groups are allowed", len(o.FilterGroups), maxSearchFilterGroups)
+	}
+	for i, group := range o.FilterGroups {
+		if len(group.Filters) > maxSearchFiltersPerGroup {
+			return fmt.Errorf("too many filters in filter group %d: %d, up to %d filters are allowed in a group", i, len(group.Filters), maxSearchFiltersPerGroup)
+		}
+		for j, f := range group.Filters {
+			if err := f.validate(); err != nil {
+				return fmt.Errorf("invalid filter %d in filter group %d: %w", j, i, err)
+			}
+		}
+	}
+	for i, sort := range o.Sorts {
+		if sort.Direction != SortDirectionAscending && sort.Direction != SortDirectionDescending {
+			return fmt.Errorf("%w: %q in sort %d on %q, %s or %s is expected",
+				ErrInvalidSortDirection, sort.Direction, i, sort.PropertyName, SortDirectionAscending, SortDirectionDescending)
+		}
+	}
+	return nil
+}
+
+// AddFilterGroup appends a filter group matching objects that match all the given filters.
+// Objects matching any of the filter groups are returned.
+func (o *RequestSearchOption) AddFilterGroup(filters ...Filter) *RequestSearchOption {
+	o.FilterGroups = append(o.FilterGroups, FilterGroup{Filters: filters})
+	return o
+}
+
+// FilterGroup is a set of filters that are AND'd together.
 type FilterGroup struct {
 	Filters []Filter `json:"filters,omitempty"`
 }
 
+// Filter is a condition on a property.
+// Use Values for the IN and NOT_IN operators, HighValue for BETWEEN, and Value for the other operators taking a value.
 type Filter struct {
-	Value        string `json:"value,omitempty"`
-	PropertyName string `json:"propertyName,omitempty"`
-	Operator     string `json:"operator,omitempty"`
+	Value        string   `json:"value,omitempty"`
+	Values       []string `json:"values,omitempty"`
+	HighValue    string   `json:"highValue,omitempty"`
+	PropertyName string   `json:"propertyName,omitempty"`
+	Operator     string   `json:"operator,omitempty"`
+}
+
+// FilterIn returns a filter matching objects whose property is one of the values.
+func FilterIn(propertyName string, values ...string) Filter {
+	return Filter{PropertyName: propertyName, Operator: FilterOperatorIn, Values: values}
+}
+
+// FilterNotIn returns a filter matching objects whose property is none of the values.
+func FilterNotIn(propertyName string, values ...string) Filter {
+	return Filter{PropertyName: propertyName, Operator: FilterOperatorNotIn, Values: values}
+}
+
+// FilterHasProperty returns a filter matching objects whose property has a value.
+func FilterHasProperty(propertyName string) Filter {
+	return Filter{PropertyName: propertyName, Operator: FilterOperatorHasProperty}
+}
+
+// FilterNotHasProperty returns a filter matching objects whose property has no value.
+func FilterNotHasProperty(propertyName string) Filter {
+	return Filter{PropertyName: propertyName, Operator: FilterOperatorNotHasProperty}
+}
+
+// validate checks that the filter has the values its operator takes,
+// so that a Value given to IN, which HubSpot ignores or rejects, is reported before the request.
+func (f Filter) validate() error {
+	switch f.Operator {
+	case FilterOperatorIn, FilterOperatorNotIn:
+		if len(f.Values) == 0 || f.Value != "" {
+			return fmt.Errorf("operator %s on %q takes Values instead of Value", f.Operator, f.PropertyName)
+		}
+	case FilterOperatorHasProperty, FilterOperatorNotHasProperty:
+		if f.Value != "" || len(f.Values) != 0 || f.HighValue != "" {
+			return fmt.Errorf("operator %s on %q takes no value", f.Operator, f.PropertyName)
+		}
+	case FilterOperatorBetween:
+		if f.HighValue == "" || len(f.Values) != 0 {
+			return fmt.Errorf("operator %s on %q takes Value and HighValue", f.Operator, f.PropertyName)
+		}
+	default:
+		if len(f.Values) != 0 {
+			return fmt.Errorf("operator %s on %q takes Value instead of Values", f.Operator, f.PropertyName)
+		}
+	}
+	return nil
+}
+
+// The directions of Sort. Any other direction, e.g. "desc", is rejected by RequestSearchOption.Validate before the request.
+const (
+	SortDirectionAscending  = "ASCENDING"
+	SortDirectionDescending = "DESCENDING"
+
+	// SortAscending and SortDescending are short aliases of SortDirectionAscending and SortDirectionDescending.
+	SortAscending  = SortDirectionAscending
+	SortDescending = SortDirectionDescending
+)
+
+// Sort is a sort order of the search results.
+// Direction is either SortDirectionAscending or SortDirectionDescending.
+type Sort struct {
+	PropertyName string `json:"propertyName"`
+	Direction    string `json:"direction"`
+}
+
+// WithQuery sets the free-text query.
+// HubSpot matches it against the default searchable properties of the object type, e.g. the name and domain of companies,
+// and the results must also match the filters if any.
+func (o *RequestSearchOption) WithQuery(query string) *RequestSearchOption {
+	o.Query = query
+	return o
+}
+
+// AddSort appends a sort order.
+// The results are sorted by the sorts in the order they were added, so later sorts break ties of earlier ones.
+func (o *RequestSearchOption) AddSort(propertyName, direction string) *RequestSearchOption {
+	o.Sorts = append(o.Sorts, Sort{PropertyName: propertyName, Direction: direction})
+	return o
+}
+
+// UpdatedAfter adds a filter matching objects modified at or after the given time.
+// The filter is added to every filter group, so it narrows the existing conditions.
+func (o *RequestSearchOption) UpdatedAfter(t time.Time) *RequestSearchOption {
+	return o.andFilter(Filter{
+		PropertyName: searchPropertyLastModifiedDate,
+		Operator:     FilterOperatorGreaterThanOrEqual,
+		Value:        searchTimestamp(t),
+	})
+}
+
+// CreatedBetween adds a filter matching objects created between start and end, inclusive.
+// The filter is added to every filter group, so it narrows the existing conditions.
+func (o *RequestSearchOption) CreatedBetween(start, end time.Time) *RequestSearchOption {
+	return o.andFilter(Filter{
+		PropertyName: searchPropertyCreateDate,
+		Operator:     FilterOperatorBetween,
+		Value:        searchTimestamp(start),
+		HighValue:    searchTimestamp(end),
+	})
+}
+
+// clone returns a copy of the option whose filter groups can be changed without changing those of the original.
+func (o *RequestSearchOption) clone() *RequestSearchOption {
+	opts := *o
+	opts.FilterGroups = make([]FilterGroup, len(o.FilterGroups))
+	for i, group := range o.FilterGroups {
+		opts.FilterGroups[i].Filters = append([]Filter(nil), group.Filters...)
+	}
+	return &opts
+}
+
+// andFilter adds the filter to all filter groups.
+// If there is no filter group yet, a new one is created.
+func (o *RequestSearchOption) andFilter(f Filter) *RequestSearchOption {
+	if len(o.FilterGroups) == 0 {
+		o.FilterGroups = []FilterGroup{{}}
+	}
+	for i := range o.FilterGroups {
+		o.FilterGroups[i].Filters = append(o.FilterGroups[i].Filters, f)
+	}
+	return o
+}
+
+// searchTimestamp formats the time to the Unix milliseconds expected by HubSpot search for date properties.
+func searchTimestamp(t time.Time) string {
+	return strconv.FormatInt(unixMilli(t), 10)
+}
+
+// unixMilli returns the time in Unix milliseconds in the same way as time.Time.UnixMilli, which needs Go 1.17.
+// Unlike dividing UnixNano, it does not overflow for the times before 1678 or after 2262.
+func unixMilli(t time.Time) int64 {
+	return t.Unix()*1e3 + int64(t.Nanosecond())/1e6
 }
@@ -1,7 +1,11 @@
 package hubspot_test
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"bendingspoons.com/hubspot"
@@ -15,6 +19,7 @@ func TestRequestQueryOption_setupProperties(t *testing.T) {
 		PaginateAssociations bool
 		Archived             bool
 		IDProperty           string
+		NoDefaultProperties  bool
 	}
 	type args struct {
 		defaultFields []string
@@ -75,6 +80,35 @@ func TestRequestQueryOption_setupProperties(t *testing.T) {
 				IDProperty:           "",
 			},
 		},
+		{
+			name: "Success without default properties",
+			fields: &fields{
+				Properties:          []string{"name"},
+				CustomProperties:    []string{"tel"},
+				NoDefaultProperties: true,
+			},
+			args: args{
+				defaultFields: []string{"id", "name", "age"},
+			},
+			want: &hubspot.RequestQueryOption{
+				Properties:          []string{"name", "tel"},
+				CustomProperties:    []string{"tel"},
+				NoDefaultProperties: true,
+			},
+		},
+		{
+			name: "Success without any properties",
+			fields: &fields{
+				NoDefaultProperties: true,
+			},
+			args: args{
+				defaultFields: []string{"id", "name", "age"},
+			},
+			want: &hubspot.RequestQueryOption{
+				Properties:          []string{},
+				NoDefaultProperties: true,
+			},
+		},
 		{
 			name:   "Success option is nil",
 			fields: nil,
@@ -106,6 +140,7 @@ func TestRequestQueryOption_setupProperties(t *testing.T) {
 					PaginateAssociations: tt.fields.PaginateAssociations,
 					Archived:             tt.fields.Archived,
 					IDProperty:           tt.fields.IDProperty,
+					NoDefaultProperties:  tt.fields.NoDefaultProperties,
 				}
 			}
 			got := hubspot.ExportSetupProperties(o, tt.args.defaultFields)
@@ -115,3 +150,310 @@ func TestRequestQueryOption_setupProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestSearchOption_UpdatedAfter(t *testing.T) {
+	since := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		option *hubspot.RequestSearchOption
+		want   *hubspot.RequestSearchOption
+	}{
+		{
+			name:   "Success with empty option",
+			option: &hubspot.RequestSearchOption{},
+			want: &hubspot.RequestSearchOption{
+				FilterGroups: []hubspot.FilterGroup{
+					{
+						Filters: []hubspot.Filter{
+							{PropertyName: "hs_lastmodifieddate", Operator: hubspot.FilterOperatorGreaterThanOrEqual, Value: "1646136000000"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Success with existing filter groups",
+			option: &hubspot.RequestSearchOption{
+				FilterGroups: []hubspot.FilterGroup{
+					{Filters: []hubspot.Filter{{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "a"}}},
+					{Filters: []hubspot.Filter{{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "b"}}},
+				},
+			},
+			want: &hubspot.RequestSearchOption{
+				FilterGroups: []hubspot.FilterGroup{
+					{
+						Filters: []hubspot.Filter{
+							{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "a"},
+							{PropertyName: "hs_lastmodifieddate", Operator: hubspot.FilterOperatorGreaterThanOrEqual, Value: "1646136000000"},
+						},
+					},
+					{
+						Filters: []hubspot.Filter{
+							{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "b"},
+							{PropertyName: "hs_lastmodifieddate", Operator: hubspot.FilterOperatorGreaterThanOrEqual, Value: "1646136000000"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.option.UpdatedAfter(since)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UpdatedAfter() response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequestSearchOption_CreatedBetween(t *testing.T) {
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 3, 1, 21, 0, 0, 123000000, time.FixedZone("JST", 9*60*60))
+	want := &hubspot.RequestSearchOption{
+		FilterGroups: []hubspot.FilterGroup{
+			{
+				Filters: []hubspot.Filter{
+					{PropertyName: "hs_createdate", Operator: hubspot.FilterOperatorBetween, Value: "1646136000000", HighValue: "1646136000123"},
+				},
+			},
+		},
+	}
+	got := (&hubspot.RequestSearchOption{}).CreatedBetween(start, end)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreatedBetween() response mismatch (-want +got):%s", diff)
+	}
+
+	far := (&hubspot.RequestSearchOption{}).CreatedBetween(time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+	if f := far.FilterGroups[0].Filters[0]; f.Value != "-11676096000000" || f.HighValue != "10413792000000" {
+		t.Errorf("CreatedBetween() far dates mismatch: want -11676096000000 and 10413792000000 got %s and %s", f.Value, f.HighValue)
+	}
+}
+
+func TestRequestSearchOption_AddSort(t *testing.T) {
+	want := &hubspot.RequestSearchOption{
+		Sorts: []hubspot.Sort{
+			{PropertyName: "name", Direction: "ASCENDING"},
+			{PropertyName: "createdate", Direction: "DESCENDING"},
+		},
+	}
+	got := (&hubspot.RequestSearchOption{}).AddSort("name", "ASCENDING").AddSort("createdate", "DESCENDING")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AddSort() response mismatch (-want +got):%s", diff)
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %s", err)
+	}
+	wantJSON := `{"sorts":[{"propertyName":"name","direction":"ASCENDING"},{"propertyName":"createdate","direction":"DESCENDING"}]}`
+	if string(b) != wantJSON {
+		t.Errorf("AddSort() json mismatch: want %s got %s", wantJSON, string(b))
+	}
+}
+
+func TestRequestSearchOption_WithQuery(t *testing.T) {
+	got := (&hubspot.RequestSearchOption{}).WithQuery("acme").UpdatedAfter(time.Date(2019, 10, 30, 3, 30, 17, 883000000, time.UTC))
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %s", err)
+	}
+	wantJSON := `{"query":"acme","filterGroups":[{"filters":[{"value":"1572406217883","propertyName":"hs_lastmodifieddate","operator":"GTE"}]}]}`
+	if string(b) != wantJSON {
+		t.Errorf("WithQuery() json mismatch: want %s got %s", wantJSON, string(b))
+	}
+}
+
+func TestRequestSearchOption_setupProperties(t *testing.T) {
+	defaultFields := []string{"id", "name", "industry"}
+	tests := []struct {
+		name   string
+		option *hubspot.RequestSearchOption
+		want   *hubspot.RequestSearchOption
+	}{
+		{
+			name:   "Success option is nil",
+			option: nil,
+			want: &hubspot.RequestSearchOption{
+				Properties: []string{"id", "name", "industry"},
+			},
+		},
+		{
+			name: "Success with custom properties",
+			option: &hubspot.RequestSearchOption{
+				FilterGroups:     []hubspot.FilterGroup{{Filters: []hubspot.Filter{{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "a"}}}},
+				CustomProperties: []string{"custom_a"},
+			},
+			want: &hubspot.RequestSearchOption{
+				FilterGroups:     []hubspot.FilterGroup{{Filters: []hubspot.Filter{{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "a"}}}},
+				Properties:       []string{"id", "name", "industry", "custom_a"},
+				CustomProperties: []string{"custom_a"},
+			},
+		},
+		{
+			name: "Success with explicit properties",
+			option: &hubspot.RequestSearchOption{
+				Properties:       []string{"name"},
+				CustomProperties: []string{"custom_a"},
+			},
+			want: &hubspot.RequestSearchOption{
+				Properties:       []string{"name"},
+				CustomProperties: []string{"custom_a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hubspot.ExportSearchSetupProperties(tt.option, defaultFields)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("setupProperties() response mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequestSearchOption_Validate(t *testing.T) {
+	filter := hubspot.Filter{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "Acme"}
+	filters := func(n int) []hubspot.Filter {
+		fs := make([]hubspot.Filter, n)
+		for i := range fs {
+			fs[i] = filter
+		}
+		return fs
+	}
+	tests := []struct {
+		name    string
+		option  *hubspot.RequestSearchOption
+		wantErr bool
+	}{
+		{
+			name:   "Nil option",
+			option: nil,
+		},
+		{
+			name: "Within the limits",
+			option: (&hubspot.RequestSearchOption{}).
+				AddFilterGroup(filters(6)...).AddFilterGroup(filter).AddFilterGroup(filter).AddFilterGroup(filter).AddFilterGroup(filter),
+		},
+		{
+			name: "Too many filter groups",
+			option: (&hubspot.RequestSearchOption{}).
+				AddFilterGroup(filter).AddFilterGroup(filter).AddFilterGroup(filter).AddFilterGroup(filter).AddFilterGroup(filter).AddFilterGroup(filter),
+			wantErr: true,
+		},
+		{
+			name:    "Too many filters in a group",
+			option:  (&hubspot.RequestSearchOption{}).AddFilterGroup(filter).AddFilterGroup(filters(7)...),
+			wantErr: true,
+		},
+		{
+			name: "Operators with their values",
+			option: (&hubspot.RequestSearchOption{}).AddFilterGroup(
+				hubspot.FilterIn("hubspot_owner_id", "1", "2"),
+				hubspot.FilterNotHasProperty("phone"),
+				hubspot.Filter{PropertyName: "hs_createdate", Operator: hubspot.FilterOperatorBetween, Value: "1", HighValue: "2"},
+			),
+		},
+		{
+			name:   "Maximum limit",
+			option: &hubspot.RequestSearchOption{Limit: 200},
+		},
+		{
+			name:    "Too large limit",
+			option:  &hubspot.RequestSearchOption{Limit: 201},
+			wantErr: true,
+		},
+		{
+			name:    "IN with Value",
+			option:  (&hubspot.RequestSearchOption{}).AddFilterGroup(hubspot.Filter{PropertyName: "hubspot_owner_id", Operator: hubspot.FilterOperatorIn, Value: "1"}),
+			wantErr: true,
+		},
+		{
+			name:    "HAS_PROPERTY with Value",
+			option:  (&hubspot.RequestSearchOption{}).AddFilterGroup(hubspot.Filter{PropertyName: "phone", Operator: hubspot.FilterOperatorHasProperty, Value: "1"}),
+			wantErr: true,
+		},
+		{
+			name:    "BETWEEN without HighValue",
+			option:  (&hubspot.RequestSearchOption{}).AddFilterGroup(hubspot.Filter{PropertyName: "hs_createdate", Operator: hubspot.FilterOperatorBetween, Value: "1"}),
+			wantErr: true,
+		},
+		{
+			name:    "EQ with Values",
+			option:  (&hubspot.RequestSearchOption{}).AddFilterGroup(hubspot.Filter{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Values: []string{"Acme"}}),
+			wantErr: true,
+		},
+		{
+			name:   "Sort directions",
+			option: (&hubspot.RequestSearchOption{}).AddSort("name", hubspot.SortDirectionAscending).AddSort("createdate", hubspot.SortDirectionDescending),
+		},
+		{
+			name:   "Sort direction aliases",
+			option: (&hubspot.RequestSearchOption{}).AddSort("name", hubspot.SortAscending).AddSort("createdate", hubspot.SortDescending),
+		},
+		{
+			name:    "Lowercase sort direction",
+			option:  (&hubspot.RequestSearchOption{}).AddSort("createdate", "desc"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty sort direction",
+			option:  (&hubspot.RequestSearchOption{}).AddSort("name", hubspot.SortDirectionAscending).AddSort("createdate", ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.option.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error mismatch: wantErr %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRequestSearchOption_Validate_SortDirection(t *testing.T) {
+	conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: []byte(`{"total":0,"results":[]}`)}
+	option := (&hubspot.RequestSearchOption{}).AddSort("createdate", "DESC")
+
+	_, err := hubspot.NewMockClient(conf).CRM.Company.Search(&hubspot.Company{}, option)
+	if !errors.Is(err, hubspot.ErrInvalidSortDirection) {
+		t.Errorf("Search() error mismatch: want ErrInvalidSortDirection got %v", err)
+	}
+	if len(conf.Requests) != 0 {
+		t.Errorf("Search() requests mismatch: want no request got %d", len(conf.Requests))
+	}
+}
+
+func TestRequestSearchOption_AddFilterGroup(t *testing.T) {
+	got := (&hubspot.RequestSearchOption{}).
+		AddFilterGroup(hubspot.Filter{PropertyName: "name", Operator: hubspot.FilterOperatorEqual, Value: "Acme"}).
+		AddFilterGroup(hubspot.Filter{PropertyName: "domain", Operator: hubspot.FilterOperatorEqual, Value: "acme.com"})
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %s", err)
+	}
+	wantJSON := `{"filterGroups":[{"filters":[{"value":"Acme","propertyName":"name","operator":"EQ"}]},{"filters":[{"value":"acme.com","propertyName":"domain","operator":"EQ"}]}]}`
+	if string(b) != wantJSON {
+		t.Errorf("AddFilterGroup() json mismatch: want %s got %s", wantJSON, string(b))
+	}
+}
+
+func TestFilter_JSON(t *testing.T) {
+	got := (&hubspot.RequestSearchOption{}).AddFilterGroup(
+		hubspot.FilterIn("hubspot_owner_id", "1", "2"),
+		hubspot.FilterNotIn("lifecyclestage", "customer"),
+		hubspot.FilterHasProperty("domain"),
+		hubspot.FilterNotHasProperty("phone"),
+	)
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %s", err)
+	}
+	wantJSON := `{"filterGroups":[{"filters":[{"values":["1","2"],"propertyName":"hubspot_owner_id","operator":"IN"},{"values":["customer"],"propertyName":"lifecyclestage","operator":"NOT_IN"},{"propertyName":"domain","operator":"HAS_PROPERTY"},{"propertyName":"phone","operator":"NOT_HAS_PROPERTY"}]}]}`
+	if string(b) != wantJSON {
+		t.Errorf("Filter json mismatch: want %s got %s", wantJSON, string(b))
+	}
+}
@@ -0,0 +1,119 @@
+package hubspot
+
+const (
+	quoteBasePath = "quotes"
+)
+
+// QuoteService is an interface of quote endpoints of the HubSpot API.
+// HubSpot quotes share pricing information with buyers, and are usually associated with a deal and its line items.
+// Quotes are read and associated here, and are created and published in HubSpot.
+// Reference: https://developers.hubspot.com/docs/api/crm/quotes
+type QuoteService interface {
+	Get(quoteID string, quote interface{}, option *RequestQueryOption) (*ResponseResource, error)
+	GetAll(quote interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error)
+	Search(quote interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error)
+	AssociateAnotherObj(quoteID string, conf *AssociationConfig) (*ResponseResource, error)
+}
+
+// QuoteServiceOp handles communication with the quote related methods of the HubSpot API.
+type QuoteServiceOp struct {
+	quotePath string
+	client    *Client
+}
+
+var _ QuoteService = (*QuoteServiceOp)(nil)
+
+// Quote statuses, the value of Quote.HsStatus.
+const (
+	QuoteStatusDraft             = "DRAFT"
+	QuoteStatusPendingApproval   = "PENDING_APPROVAL"
+	QuoteStatusApprovalNotNeeded = "APPROVAL_NOT_NEEDED"
+	QuoteStatusApproved          = "APPROVED"
+	QuoteStatusRejected          = "REJECTED"
+)
+
+// Quote represents a HubSpot quote.
+// HsQuoteLink is the public URL of a published quote, and HsPdfDownloadLink is the URL to download it as PDF.
+type Quote struct {
+	HsTitle            *HsStr   `json:"hs_title,omitempty"`
+	HsStatus           *HsStr   `json:"hs_status,omitempty"`
+	HsExpirationDate   *HsTime  `json:"hs_expiration_date,omitempty"`
+	HsQuoteAmount      *HsFloat `json:"hs_quote_amount,omitempty"`
+	HsCurrency         *HsStr   `json:"hs_currency,omitempty"`
+	HsQuoteNumber      *HsStr   `json:"hs_quote_number,omitempty"`
+	HsQuoteLink        *HsStr   `json:"hs_quote_link,omitempty"`
+	HsPdfDownloadLink  *HsStr   `json:"hs_pdf_download_link,omitempty"`
+	HsPublicURLKey     *HsStr   `json:"hs_public_url_key,omitempty"`
+	HsObjectID         *HsStr   `json:"hs_object_id,omitempty"`
+	HsCreateDate       *HsTime  `json:"hs_createdate,omitempty"`
+	HsLastModifiedDate *HsTime  `json:"hs_lastmodifieddate,omitempty"`
+	HubspotOwnerID     *HsStr   `json:"hubspot_owner_id,omitempty"`
+}
+
+var defaultQuoteFields = []string{
+	"hs_title",
+	"hs_status",
+	"hs_expiration_date",
+	"hs_quote_amount",
+	"hs_currency",
+	"hs_quote_number",
+	"hs_quote_link",
+	"hs_pdf_download_link",
+	"hs_public_url_key",
+	"hs_object_id",
+	"hs_createdate",
+	"hs_lastmodifieddate",
+	"hubspot_owner_id",
+}
+
+// Get gets a quote.
+// In order to bind the get content, a structure must be specified as an argument.
+// Also, if you want to gets a custom field, you need to specify the field name.
+// If you specify a non-existent field, it will be ignored.
+// e.g. &hubspot.RequestQueryOption{ Properties: []string{"custom_a", "custom_b"}}
+func (s *QuoteServiceOp) Get(quoteID string, quote interface{}, option *RequestQueryOption) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: quote}
+	if err := s.client.Get(s.quotePath+"/"+quoteID, resource, option.setupProperties(defaultQuoteFields)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetAll gets a page of quotes.
+// Use RequestQueryOption.Limit and RequestQueryOption.After to get the following pages.
+// The properties of each quote are bound to a new structure of the same type as the argument.
+// If there is no quote, an empty Results is returned without error.
+// RequestQueryOption.Limit is up to 100, and an error wrapping ErrInvalidLimit is returned for a larger one.
+func (s *QuoteServiceOp) GetAll(quote interface{}, option *RequestQueryOption) (*ResponseResourceMulti, error) {
+	if err := option.validateLimit(maxListLimit); err != nil {
+		return nil, err
+	}
+	page := &pagedResponse{}
+	if err := s.client.Get(s.quotePath, page, option.setupProperties(defaultQuoteFields)); err != nil {
+		return nil, err
+	}
+	return s.client.decodePage(page, quote)
+}
+
+// Search finds quotes.
+// In order to bind the get content, a structure must be specified as an argument.
+// The default fields are requested in the same way as Get.
+// e.g. &hubspot.RequestSearchOption{ CustomProperties: []string{"custom_a", "custom_b"}}
+// An error is returned without making the request if the filter groups exceed the limits of HubSpot.
+func (s *QuoteServiceOp) Search(quote interface{}, option *RequestSearchOption) (*ResponseResourceMulti, error) {
+	resource := &ResponseResourceMulti{}
+	if err := s.client.search(s.quotePath, option.setupProperties(defaultQuoteFields), quote, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// AssociateAnotherObj associates Quote with another HubSpot objects,
+// e.g. a deal with AssociationTypeQuoteToDeal or a line item with AssociationTypeQuoteToLineItem.
+func (s *QuoteServiceOp) AssociateAnotherObj(quoteID string, conf *AssociationConfig) (*ResponseResource, error) {
+	resource := &ResponseResource{Properties: &Quote{}}
+	if err := s.client.Put(s.quotePath+"/"+quoteID+"/"+conf.makeAssociationPath(), nil, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,97 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestQuoteServiceOp_Get(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"quote001","properties":{"hs_title":"Acme renewal","hs_status":"APPROVAL_NOT_NEEDED","hs_expiration_date":"2019-12-07T16:50:06.678Z","hs_quote_amount":"1500.00","hs_quote_link":"https://example.hubspotquote.com/abc","hs_pdf_download_link":"https://example.hubspotquote.com/abc.pdf"},"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID: "quote001",
+		Properties: &hubspot.Quote{
+			HsTitle:           hubspot.NewString("Acme renewal"),
+			HsStatus:          hubspot.NewString(hubspot.QuoteStatusApprovalNotNeeded),
+			HsExpirationDate:  &updatedAt,
+			HsQuoteAmount:     hubspot.NewFloat(1500),
+			HsQuoteLink:       hubspot.NewString("https://example.hubspotquote.com/abc"),
+			HsPdfDownloadLink: hubspot.NewString("https://example.hubspotquote.com/abc.pdf"),
+		},
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Quote.Get("quote001", &hubspot.Quote{}, nil)
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/objects/quotes/quote001"; conf.Requests[0].URL.Path != want {
+		t.Errorf("Get() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestQuoteServiceOp_Search(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"total":1,"results":[{"id":"quote001","properties":{"hs_title":"Acme renewal","hs_status":"APPROVED"}}]}`),
+	}
+	option := (&hubspot.RequestSearchOption{Properties: []string{"hs_title", "hs_status"}}).
+		AddFilterGroup(hubspot.FilterIn("hs_status", hubspot.QuoteStatusApproved, hubspot.QuoteStatusApprovalNotNeeded))
+	want := &hubspot.ResponseResourceMulti{
+		Total: 1,
+		Results: []hubspot.ResponseResource{
+			{ID: "quote001", Properties: &hubspot.Quote{HsTitle: hubspot.NewString("Acme renewal"), HsStatus: hubspot.NewString(hubspot.QuoteStatusApproved)}},
+		},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Quote.Search(&hubspot.Quote{}, option)
+	if err != nil {
+		t.Fatalf("Search() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Search() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/objects/quotes/search"; conf.Requests[0].URL.Path != want {
+		t.Errorf("Search() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestQuoteServiceOp_AssociateAnotherObj(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"quote001","properties":{"hs_title":"Acme renewal"},"associations":{"deals":{"results":[{"id":"deal001","type":"quote_to_deal"}]}}}`),
+	}
+	want := &hubspot.ResponseResource{
+		ID:           "quote001",
+		Properties:   &hubspot.Quote{HsTitle: hubspot.NewString("Acme renewal")},
+		Associations: hubspot.Associations{"deals": {Results: []hubspot.AssociationResult{{ID: "deal001", Type: string(hubspot.AssociationTypeQuoteToDeal)}}}},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Quote.AssociateAnotherObj("quote001", &hubspot.AssociationConfig{
+		ToObject:   hubspot.ObjectTypeDeal,
+		ToObjectID: "deal001",
+		Type:       hubspot.AssociationTypeQuoteToDeal,
+	})
+	if err != nil {
+		t.Fatalf("AssociateAnotherObj() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AssociateAnotherObj() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/objects/quotes/quote001/associations/deals/deal001/quote_to_deal"; req.Method != http.MethodPut || req.URL.Path != want {
+		t.Errorf("AssociateAnotherObj() request mismatch: want PUT %s got %s %s", want, req.Method, req.URL.Path)
+	}
+}
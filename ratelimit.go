@@ -0,0 +1,80 @@
+package hubspot
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitRemainingHeader is the header HubSpot sets to the number of requests remaining in the current window.
+const rateLimitRemainingHeader = "X-HubSpot-RateLimit-Remaining"
+
+// rateLimitInterval is the window of the limit set by WithRateLimit, which is the window of HubSpot rate limits.
+var rateLimitInterval = 10 * time.Second
+
+// defaultRateLimitPerSecond is the rate of WithRateLimitPerSecond when it is not set, which is the standard limit
+// of HubSpot, 100 requests per 10 seconds.
+const defaultRateLimitPerSecond = 10
+
+// rateLimiter is a token bucket holding up to burst tokens, where a token is added every every.
+// The bucket starts full.
+type rateLimiter struct {
+	mu     sync.Mutex
+	burst  int
+	every  time.Duration
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(burst int, every time.Duration) *rateLimiter {
+	return &rateLimiter{burst: burst, every: every, tokens: float64(burst), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last refill. The caller must hold mu.
+func (l *rateLimiter) refill(now time.Time) {
+	l.tokens += float64(now.Sub(l.last)) / float64(l.every)
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+}
+
+// wait blocks until a token is available and takes it, or returns the error of the context if it is done first.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		l.refill(time.Now())
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) * float64(l.every))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observe lowers the tokens to the remaining requests reported by HubSpot, which are fewer than expected
+// when other clients share the limit of the app.
+func (l *rateLimiter) observe(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get(rateLimitRemainingHeader))
+	if err != nil || remaining < 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+}
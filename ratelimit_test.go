@@ -0,0 +1,112 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bendingspoons.com/hubspot"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	interval := *hubspot.ExportRateLimitInterval
+	*hubspot.ExportRateLimitInterval = 100 * time.Millisecond
+	defer func() { *hubspot.ExportRateLimitInterval = interval }()
+
+	t.Run("Throttle beyond the limit", func(t *testing.T) {
+		c := hubspot.NewMockClient(&hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}})
+		if err := hubspot.WithRateLimit(2)(c); err != nil {
+			t.Fatalf("WithRateLimit() unexpected error: %s", err)
+		}
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if err := c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil); err != nil {
+				t.Fatalf("CreateAndDoWithContext() unexpected error: %s", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+			t.Errorf("CreateAndDoWithContext() did not throttle: elapsed %s", elapsed)
+		}
+	})
+
+	t.Run("Stop waiting when the context is done", func(t *testing.T) {
+		c := hubspot.NewMockClient(&hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}})
+		if err := hubspot.WithRateLimit(1)(c); err != nil {
+			t.Fatalf("WithRateLimit() unexpected error: %s", err)
+		}
+
+		_ = c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := c.CreateAndDoWithContext(ctx, http.MethodGet, "crm/v3/objects/companies", nil, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("CreateAndDoWithContext() error mismatch: want context.DeadlineExceeded got %v", err)
+		}
+	})
+
+	t.Run("Adapt to the remaining requests reported by HubSpot", func(t *testing.T) {
+		conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{"X-Hubspot-Ratelimit-Remaining": []string{"0"}}}
+		c := hubspot.NewMockClient(conf)
+		if err := hubspot.WithRateLimit(10)(c); err != nil {
+			t.Fatalf("WithRateLimit() unexpected error: %s", err)
+		}
+
+		_ = c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil)
+		start := time.Now()
+		_ = c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil)
+		if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+			t.Errorf("CreateAndDoWithContext() did not wait for the reported allowance: elapsed %s", elapsed)
+		}
+	})
+
+	t.Run("Reject a non-positive limit", func(t *testing.T) {
+		if err := hubspot.WithRateLimit(0)(hubspot.NewMockClient(&hubspot.MockConfig{})); err == nil {
+			t.Error("WithRateLimit() error mismatch: want error got nil")
+		}
+	})
+}
+
+func TestWithRateLimitPerSecond(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		burst     int
+		requests  int
+		wantMin   time.Duration
+		wantErr   bool
+	}{
+		{name: "Throttle beyond the burst", perSecond: 20, burst: 1, requests: 3, wantMin: 90 * time.Millisecond},
+		{name: "Allow the burst", perSecond: 1, burst: 3, requests: 3},
+		{name: "Default to the standard limit", requests: 10},
+		{name: "Reject a negative rate", perSecond: -1, wantErr: true},
+		{name: "Reject a negative burst", perSecond: 10, burst: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubspot.NewMockClient(&hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}})
+			err := hubspot.WithRateLimitPerSecond(tt.perSecond, tt.burst)(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithRateLimitPerSecond() error mismatch: want error %t got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			start := time.Now()
+			for i := 0; i < tt.requests; i++ {
+				if err := c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil); err != nil {
+					t.Fatalf("CreateAndDoWithContext() unexpected error: %s", err)
+				}
+			}
+			elapsed := time.Since(start)
+			if elapsed < tt.wantMin {
+				t.Errorf("CreateAndDoWithContext() did not throttle: want at least %s got %s", tt.wantMin, elapsed)
+			}
+			if tt.wantMin == 0 && elapsed > 50*time.Millisecond {
+				t.Errorf("CreateAndDoWithContext() throttled within the burst: elapsed %s", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package hubspot
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Response headers of the HubSpot rate limits.
+// Reference: https://developers.hubspot.com/docs/api/usage-details#rate-limits
+const (
+	rateLimitMaxHeader            = "X-HubSpot-RateLimit-Max"
+	rateLimitIntervalHeader       = "X-HubSpot-RateLimit-Interval-Milliseconds"
+	rateLimitDailyHeader          = "X-HubSpot-RateLimit-Daily"
+	rateLimitDailyRemainingHeader = "X-HubSpot-RateLimit-Daily-Remaining"
+)
+
+// ResponseMeta is the metadata of a response of HubSpot, passed to the function set by WithResponseObserver.
+type ResponseMeta struct {
+	Method string
+	// Path is the path of the request, e.g. "/crm/v3/objects/companies/123".
+	Path       string
+	StatusCode int
+	// RequestID is the X-HubSpot-Correlation-Id HubSpot assigns to each request, which HubSpot support asks for.
+	RequestID string
+	RateLimit RateLimitInfo
+}
+
+// RateLimitInfo is the state of the rate limits reported by HubSpot in the response headers.
+// A field is 0 if HubSpot did not send its header, e.g. the daily limits are not sent for OAuth apps.
+type RateLimitInfo struct {
+	// Max is the number of requests allowed per interval.
+	Max int
+	// Remaining is the number of requests remaining in the current interval.
+	Remaining            int
+	IntervalMilliseconds int
+	// Daily is the number of requests allowed per day.
+	Daily int
+	// DailyRemaining is the number of requests remaining in the current day.
+	DailyRemaining int
+}
+
+// newResponseMeta returns the metadata of the response to the request.
+func newResponseMeta(req *http.Request, resp *http.Response) *ResponseMeta {
+	return &ResponseMeta{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(correlationIDHeader),
+		RateLimit: RateLimitInfo{
+			Max:                  headerInt(resp.Header, rateLimitMaxHeader),
+			Remaining:            headerInt(resp.Header, rateLimitRemainingHeader),
+			IntervalMilliseconds: headerInt(resp.Header, rateLimitIntervalHeader),
+			Daily:                headerInt(resp.Header, rateLimitDailyHeader),
+			DailyRemaining:       headerInt(resp.Header, rateLimitDailyRemainingHeader),
+		},
+	}
+}
+
+// headerInt returns the integer value of the header, or 0 if it is absent or not an integer.
+func headerInt(header http.Header, key string) int {
+	n, _ := strconv.Atoi(header.Get(key))
+	return n
+}
+
+// observeResponse passes the metadata of the response to the observer set by WithResponseObserver.
+func (c *Client) observeResponse(req *http.Request, resp *http.Response) {
+	c.responseObserver(req.Context(), newResponseMeta(req, resp))
+}
@@ -0,0 +1,109 @@
+package hubspot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestWithResponseObserver(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *hubspot.MockConfig
+		want    *hubspot.ResponseMeta
+		wantErr bool
+	}{
+		{
+			name: "Success response",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusOK,
+				Header: http.Header{
+					"X-Hubspot-Correlation-Id":                  []string{"aeb5f871-7f07-4993-9211-075dc63e7cbf"},
+					"X-Hubspot-Ratelimit-Max":                   []string{"100"},
+					"X-Hubspot-Ratelimit-Remaining":             []string{"99"},
+					"X-Hubspot-Ratelimit-Interval-Milliseconds": []string{"10000"},
+					"X-Hubspot-Ratelimit-Daily":                 []string{"250000"},
+					"X-Hubspot-Ratelimit-Daily-Remaining":       []string{"249999"},
+				},
+				Body: []byte(`{"id":"company001"}`),
+			},
+			want: &hubspot.ResponseMeta{
+				Method:     http.MethodGet,
+				Path:       "/crm/v3/objects/companies/company001",
+				StatusCode: http.StatusOK,
+				RequestID:  "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+				RateLimit: hubspot.RateLimitInfo{
+					Max:                  100,
+					Remaining:            99,
+					IntervalMilliseconds: 10000,
+					Daily:                250000,
+					DailyRemaining:       249999,
+				},
+			},
+		},
+		{
+			name: "Error response",
+			conf: &hubspot.MockConfig{
+				Status: http.StatusNotFound,
+				Header: http.Header{"X-Hubspot-Correlation-Id": []string{"aeb5f871-7f07-4993-9211-075dc63e7cbf"}},
+				Body:   []byte(`{"status":"error","message":"resource not found","correlationId":"aeb5f871-7f07-4993-9211-075dc63e7cbf","category":"OBJECT_NOT_FOUND"}`),
+			},
+			want: &hubspot.ResponseMeta{
+				Method:     http.MethodGet,
+				Path:       "/crm/v3/objects/companies/company001",
+				StatusCode: http.StatusNotFound,
+				RequestID:  "aeb5f871-7f07-4993-9211-075dc63e7cbf",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			type ctxKey struct{}
+			ctx := context.WithValue(context.Background(), ctxKey{}, "caller")
+			var got []*hubspot.ResponseMeta
+			c := hubspot.NewMockClient(tt.conf)
+			if err := hubspot.WithResponseObserver(func(ctx context.Context, meta *hubspot.ResponseMeta) {
+				if ctx.Value(ctxKey{}) != "caller" {
+					t.Error("WithResponseObserver() context mismatch: want the context of the request")
+				}
+				got = append(got, meta)
+			})(c); err != nil {
+				t.Fatalf("WithResponseObserver() unexpected error: %s", err)
+			}
+
+			err := c.CreateAndDoWithContext(ctx, http.MethodGet, "crm/v3/objects/companies/company001", nil, nil, &hubspot.ResponseResource{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateAndDoWithContext() error mismatch: want error %t got %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff([]*hubspot.ResponseMeta{tt.want}, got); diff != "" {
+				t.Errorf("WithResponseObserver() metadata mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+
+	t.Run("Reject a nil observer", func(t *testing.T) {
+		if err := hubspot.WithResponseObserver(nil)(hubspot.NewMockClient(&hubspot.MockConfig{})); err == nil {
+			t.Error("WithResponseObserver() error mismatch: want error got nil")
+		}
+	})
+
+	t.Run("Not called without a response", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		c := hubspot.NewMockClientWithHTTPClient(&http.Client{Transport: &failingRoundTripper{err: errBoom}})
+		called := false
+		if err := hubspot.WithResponseObserver(func(context.Context, *hubspot.ResponseMeta) { called = true })(c); err != nil {
+			t.Fatalf("WithResponseObserver() unexpected error: %s", err)
+		}
+		if err := c.CreateAndDoWithContext(context.Background(), http.MethodGet, "crm/v3/objects/companies", nil, nil, nil); !errors.Is(err, errBoom) {
+			t.Fatalf("CreateAndDoWithContext() error mismatch: want %v got %v", errBoom, err)
+		}
+		if called {
+			t.Error("WithResponseObserver() called without a response")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package hubspot
+
+const (
+	schemaBasePath = "schemas"
+)
+
+// SchemaService is an interface of custom object schema endpoints of the HubSpot API.
+// A schema defines a custom object type, with its properties and the object types it can be associated with.
+// Use ObjectService to operate on the objects of a custom object type, with Schema.ObjectType as the object type.
+// Reference: https://developers.hubspot.com/docs/api/crm/crm-custom-objects
+type SchemaService interface {
+	List() ([]*Schema, error)
+	Get(objectType ObjectType) (*Schema, error)
+}
+
+// SchemaServiceOp handles communication with the schema related methods of the HubSpot API.
+type SchemaServiceOp struct {
+	schemaPath string
+	client     *Client
+}
+
+var _ SchemaService = (*SchemaServiceOp)(nil)
+
+// Schema is the definition of a custom object type.
+type Schema struct {
+	ID string `json:"id,omitempty"`
+	// ObjectTypeID is the ID of the object type such as "2-123456", used in the paths of the object and association endpoints.
+	ObjectTypeID string `json:"objectTypeId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	// FullyQualifiedName is the name of the object type prefixed by the portal such as "p123456_cars".
+	FullyQualifiedName         string              `json:"fullyQualifiedName,omitempty"`
+	Labels                     *SchemaLabels       `json:"labels,omitempty"`
+	PrimaryDisplayProperty     string              `json:"primaryDisplayProperty,omitempty"`
+	SecondaryDisplayProperties []string            `json:"secondaryDisplayProperties,omitempty"`
+	RequiredProperties         []string            `json:"requiredProperties,omitempty"`
+	SearchableProperties       []string            `json:"searchableProperties,omitempty"`
+	Properties                 []*Property         `json:"properties,omitempty"`
+	Associations               []SchemaAssociation `json:"associations,omitempty"`
+	Archived                   bool                `json:"archived,omitempty"`
+	CreatedAt                  *HsTime             `json:"createdAt,omitempty"`
+	UpdatedAt                  *HsTime             `json:"updatedAt,omitempty"`
+}
+
+// ObjectType returns the object type of the schema, to be used with ObjectService and AssociationService.
+func (s *Schema) ObjectType() ObjectType {
+	return ObjectType(s.ObjectTypeID)
+}
+
+// SchemaLabels is the singular and plural labels of a custom object type.
+type SchemaLabels struct {
+	Singular string `json:"singular,omitempty"`
+	Plural   string `json:"plural,omitempty"`
+}
+
+// SchemaAssociation is an association type defined between a custom object type and another object type.
+type SchemaAssociation struct {
+	// ID is the ID of the association type.
+	ID               string  `json:"id,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	FromObjectTypeID string  `json:"fromObjectTypeId,omitempty"`
+	ToObjectTypeID   string  `json:"toObjectTypeId,omitempty"`
+	CreatedAt        *HsTime `json:"createdAt,omitempty"`
+	UpdatedAt        *HsTime `json:"updatedAt,omitempty"`
+}
+
+type schemaList struct {
+	Results []*Schema `json:"results"`
+}
+
+// List gets the schemas of all custom object types of the account.
+func (s *SchemaServiceOp) List() ([]*Schema, error) {
+	resource := &schemaList{}
+	if err := s.client.Get(s.schemaPath, resource, nil); err != nil {
+		return nil, err
+	}
+	return resource.Results, nil
+}
+
+// Get gets the schema of a custom object type.
+// The objectType is the object type ID such as "2-123456" or the fully qualified name such as "p123456_cars".
+func (s *SchemaServiceOp) Get(objectType ObjectType) (*Schema, error) {
+	resource := &Schema{}
+	if err := s.client.Get(s.schemaPath+"/"+string(objectType), resource, nil); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,67 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+const carSchemaBody = `{"id":"123","objectTypeId":"2-123456","name":"cars","fullyQualifiedName":"p123456_cars","labels":{"singular":"Car","plural":"Cars"},"primaryDisplayProperty":"model","requiredProperties":["model"],"searchableProperties":["model"],"properties":[{"name":"model","label":"Model","type":"string","fieldType":"text","groupName":"car_information"}],"associations":[{"id":"105","name":"car_to_contact","fromObjectTypeId":"2-123456","toObjectTypeId":"0-1"}],"createdAt":"2019-10-30T03:30:17.883Z","updatedAt":"2019-12-07T16:50:06.678Z"}`
+
+var carSchema = &hubspot.Schema{
+	ID:                     "123",
+	ObjectTypeID:           "2-123456",
+	Name:                   "cars",
+	FullyQualifiedName:     "p123456_cars",
+	Labels:                 &hubspot.SchemaLabels{Singular: "Car", Plural: "Cars"},
+	PrimaryDisplayProperty: "model",
+	RequiredProperties:     []string{"model"},
+	SearchableProperties:   []string{"model"},
+	Properties:             []*hubspot.Property{{Name: "model", Label: "Model", Type: "string", FieldType: "text", GroupName: "car_information"}},
+	Associations:           []hubspot.SchemaAssociation{{ID: "105", Name: "car_to_contact", FromObjectTypeID: "2-123456", ToObjectTypeID: "0-1"}},
+	CreatedAt:              &createdAt,
+	UpdatedAt:              &updatedAt,
+}
+
+func TestSchemaServiceOp_List(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[` + carSchemaBody + `]}`),
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Schema.List()
+	if err != nil {
+		t.Fatalf("List() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]*hubspot.Schema{carSchema}, got, cmpTimeOption); diff != "" {
+		t.Errorf("List() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/schemas"; conf.Requests[0].URL.Path != want {
+		t.Errorf("List() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
+
+func TestSchemaServiceOp_Get(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(carSchemaBody),
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Schema.Get("p123456_cars")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(carSchema, got, cmpTimeOption); diff != "" {
+		t.Errorf("Get() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/schemas/p123456_cars"; conf.Requests[0].URL.Path != want {
+		t.Errorf("Get() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+	if want := hubspot.ObjectType("2-123456"); got.ObjectType() != want {
+		t.Errorf("ObjectType() mismatch: want %s got %s", want, got.ObjectType())
+	}
+}
@@ -0,0 +1,64 @@
+package hubspot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxSearchIndexRetries is how many times a search with RequestSearchOption.ExpectResults is retried.
+const maxSearchIndexRetries = 3
+
+// searchIndexBackoff is the wait before the first retry of a search with RequestSearchOption.ExpectResults,
+// which doubles on each retry. HubSpot indexes the objects for search within a few seconds of the write.
+var searchIndexBackoff = 500 * time.Millisecond
+
+// search performs a search request of the object endpoint of path, shared by the Search of every object service
+// so that they validate the option, page and decode the results in the same way.
+// The option must have the properties to get set up, e.g. by RequestSearchOption.setupProperties.
+// The properties of the first result are bound to properties, and those of the others to a new structure of the same type.
+// An error is returned without making the request if the filter groups exceed the limits of HubSpot.
+func (c *Client) search(path string, option *RequestSearchOption, properties interface{}, out *ResponseResourceMulti) error {
+	return c.searchWithContext(context.Background(), path, option, properties, out)
+}
+
+// searchWithContext is search with the context of the request.
+func (c *Client) searchWithContext(ctx context.Context, path string, option *RequestSearchOption, properties interface{}, out *ResponseResourceMulti) error {
+	if err := option.Validate(); err != nil {
+		return err
+	}
+	page := &pagedResponse{}
+	for retry := 0; ; retry++ {
+		if err := c.CreateAndDoWithContext(ctx, http.MethodPost, path+"/search", option, nil, page); err != nil {
+			return err
+		}
+		if len(page.Results) != 0 || option == nil || !option.ExpectResults || option.After != "" {
+			break
+		}
+		if retry == maxSearchIndexRetries {
+			return fmt.Errorf("%w: no results after %d retries", ErrSearchNotIndexed, retry)
+		}
+		timer := time.NewTimer(searchIndexBackoff << retry)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	out.Total = page.Total
+	out.Paging = page.Paging
+	out.Results = make([]ResponseResource, 0, len(page.Results))
+	for i, raw := range page.Results {
+		result := ResponseResource{Properties: properties}
+		if i > 0 {
+			result.Properties = newPropertiesOf(properties)
+		}
+		if err := c.unmarshal(raw, &result); err != nil {
+			return err
+		}
+		out.Results = append(out.Results, result)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package hubspot
+
+// The record source properties HubSpot sets on every object from the origin of its creation and last update,
+// e.g. "INTEGRATION" for hs_object_source and the app ID for hs_object_source_id when written with this client.
+// NOTE: They are read-only, HubSpot ignores or rejects them in the properties of Create and Update.
+// The source of a write is the app of the token, so use a separate private app for each system to audit them.
+const (
+	PropertyObjectSource        = "hs_object_source"
+	PropertyObjectSourceID      = "hs_object_source_id"
+	PropertyObjectSourceLabel   = "hs_object_source_label"
+	PropertyObjectSourceDetail1 = "hs_object_source_detail_1"
+	PropertyObjectSourceUserID  = "hs_object_source_user_id"
+)
+
+// ObjectSource has the record source properties, to embed in the structure of an object such as *Company and get them
+// with RequestQueryOption.CustomProperties. The fields are not set on writes as they are omitted when nil.
+type ObjectSource struct {
+	ObjectSource        *HsStr `json:"hs_object_source,omitempty"`
+	ObjectSourceID      *HsStr `json:"hs_object_source_id,omitempty"`
+	ObjectSourceLabel   *HsStr `json:"hs_object_source_label,omitempty"`
+	ObjectSourceDetail1 *HsStr `json:"hs_object_source_detail_1,omitempty"`
+	ObjectSourceUserID  *HsStr `json:"hs_object_source_user_id,omitempty"`
+}
+
+// ObjectSourceProperties is the names of the record source properties, to set in RequestQueryOption.CustomProperties.
+var ObjectSourceProperties = []string{
+	PropertyObjectSource,
+	PropertyObjectSourceID,
+	PropertyObjectSourceLabel,
+	PropertyObjectSourceDetail1,
+	PropertyObjectSourceUserID,
+}
+
+// writeTraceID returns the objectWriteTraceId of the batch input of the id, which is the source set by WithWriteSource
+// followed by the id, or empty if the source is not set.
+func (c *Client) writeTraceID(id string) string {
+	if c.writeSource == "" {
+		return ""
+	}
+	return c.writeSource + ":" + id
+}
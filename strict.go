@@ -0,0 +1,112 @@
+package hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	unmarshalerType      = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	responseResourceType = reflect.TypeOf(ResponseResource{})
+)
+
+// unmarshal decodes the JSON data into v, and if strict decoding is enabled with WithStrictDecoding,
+// returns an error wrapping ErrUnknownField when a field of the data is not decoded into v.
+func (c *Client) unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if !c.strictDecoding {
+		return nil
+	}
+	return checkUnknownFields(data, v)
+}
+
+// checkUnknownFields returns an error wrapping ErrUnknownField with the paths of the fields of the JSON data
+// that have no field in the decoded v, in the same way as json.Decoder.DisallowUnknownFields.
+// Unlike DisallowUnknownFields, the fields are also checked through ResponseResource, which decodes itself,
+// so that the unknown properties of the structure bound to ResponseResource.Properties are reported.
+// The values of the other types implementing json.Unmarshaler, such as HsStr, are not checked.
+func checkUnknownFields(data []byte, v interface{}) error {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	var paths []string
+	findUnknownFields(doc, reflect.ValueOf(v), "", &paths)
+	if len(paths) == 0 {
+		return nil
+	}
+	sort.Strings(paths)
+	return fmt.Errorf("%w: %s", ErrUnknownField, strings.Join(paths, ", "))
+}
+
+// findUnknownFields appends to paths the path of each object field of doc that has no field in v.
+func findUnknownFields(doc interface{}, v reflect.Value, path string, paths *[]string) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Type() != responseResourceType && reflect.PtrTo(v.Type()).Implements(unmarshalerType) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		// The fields are named as they are decoded, by the hubspot tag for the properties structures using it.
+		fields, _ := mapPropertyFields(v.Type(), nil)
+		for key, child := range obj {
+			f, ok := fieldNamed(fields, key)
+			if !ok {
+				*paths = append(*paths, path+key)
+				continue
+			}
+			if fv, ok := fieldByIndex(v, f.index, false); ok {
+				findUnknownFields(child, fv, path+key+".", paths)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return
+		}
+		for i := 0; i < len(arr) && i < v.Len(); i++ {
+			findUnknownFields(arr[i], v.Index(i), path+strconv.Itoa(i)+".", paths)
+		}
+	case reflect.Map:
+		obj, ok := doc.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for key, child := range obj {
+			if mv := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())); mv.IsValid() {
+				findUnknownFields(child, mv, path+key+".", paths)
+			}
+		}
+	}
+}
+
+// fieldNamed returns the field of the name, preferring an exact match to a case-insensitive one as encoding/json does.
+func fieldNamed(fields []propertyField, name string) (propertyField, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+	return propertyField{}, false
+}
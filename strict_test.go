@@ -0,0 +1,106 @@
+package hubspot_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"bendingspoons.com/hubspot"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	type company struct {
+		Name *hubspot.HsStr `json:"name,omitempty"`
+	}
+	type mappedCompany struct {
+		TrialStatus *hubspot.HsStr `json:"trialStatus,omitempty" hubspot:"trial_status,omitempty"`
+	}
+	tests := []struct {
+		name     string
+		body     string
+		call     func(c *hubspot.Client) error
+		strict   bool
+		wantErr  bool
+		wantPath string
+	}{
+		{
+			name:   "Known fields",
+			body:   `{"id":"company001","properties":{"name":"Acme"},"createdAt":"2019-10-30T03:30:17.883Z","archived":false}`,
+			strict: true,
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Company.Get("company001", &company{}, nil)
+				return err
+			},
+		},
+		{
+			name:     "Unknown property",
+			body:     `{"id":"company001","properties":{"name":"Acme","industry":"SOFTWARE"}}`,
+			strict:   true,
+			wantErr:  true,
+			wantPath: "properties.industry",
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Company.Get("company001", &company{}, nil)
+				return err
+			},
+		},
+		{
+			name:     "Unknown field of the object",
+			body:     `{"id":"company001","properties":{"name":"Acme"},"url":"https://app.hubspot.com/contacts/1/company/1"}`,
+			strict:   true,
+			wantErr:  true,
+			wantPath: "url",
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Company.Get("company001", &company{}, nil)
+				return err
+			},
+		},
+		{
+			name:   "Property mapped by the hubspot tag",
+			body:   `{"id":"company001","properties":{"trial_status":"ACTIVE"}}`,
+			strict: true,
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Company.Get("company001", &mappedCompany{}, nil)
+				return err
+			},
+		},
+		{
+			name:     "Unknown property of a search result",
+			body:     `{"total":2,"results":[{"id":"101","properties":{"name":"Acme"}},{"id":"102","properties":{"name":"Globex","phone":"555"}}]}`,
+			strict:   true,
+			wantErr:  true,
+			wantPath: "properties.phone",
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Company.Search(&company{}, &hubspot.RequestSearchOption{Properties: []string{"name"}})
+				return err
+			},
+		},
+		{
+			name: "Disabled",
+			body: `{"id":"company001","properties":{"name":"Acme","industry":"SOFTWARE"},"url":"https://app.hubspot.com/contacts/1/company/1"}`,
+			call: func(c *hubspot.Client) error {
+				_, err := c.CRM.Company.Get("company001", &company{}, nil)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &hubspot.MockConfig{Status: http.StatusOK, Header: http.Header{}, Body: []byte(tt.body)}
+			c := hubspot.NewMockClient(conf)
+			if err := hubspot.WithStrictDecoding(tt.strict)(c); err != nil {
+				t.Fatalf("WithStrictDecoding() unexpected error: %s", err)
+			}
+			err := tt.call(c)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if !errors.Is(err, hubspot.ErrUnknownField) || !strings.HasSuffix(err.Error(), ": "+tt.wantPath) {
+				t.Errorf("error mismatch: want ErrUnknownField of %s got %v", tt.wantPath, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package hubspot
+
+import "fmt"
+
+const (
+	timelineBasePath = "timeline"
+)
+
+// TimelineService is an interface of timeline endpoints of the HubSpot API.
+// Timeline events show custom activities, e.g. "Deployed version X", on the timeline of a CRM object.
+// An event is created from an event template of an app, which defines how the event and its tokens are displayed.
+// Reference: https://developers.hubspot.com/docs/api/crm/timeline
+type TimelineService interface {
+	CreateEvent(event *TimelineEvent) (*TimelineEvent, error)
+	CreateTemplate(appID string, template *TimelineEventTemplate) (*TimelineEventTemplate, error)
+	GetTemplates(appID string) ([]*TimelineEventTemplate, error)
+	DeleteTemplate(appID, templateID string) error
+}
+
+// TimelineServiceOp handles communication with the timeline related methods of the HubSpot API.
+type TimelineServiceOp struct {
+	timelinePath string
+	client       *Client
+}
+
+var _ TimelineService = (*TimelineServiceOp)(nil)
+
+// TimelineEvent is an event on the timeline of a CRM object.
+// EventTemplateID is required, and the object is identified by ObjectID, or by Email for contacts.
+// Tokens are the values of the tokens defined in the event template, keyed by the token name.
+type TimelineEvent struct {
+	ID              string            `json:"id,omitempty"`
+	EventTemplateID string            `json:"eventTemplateId"`
+	ObjectID        string            `json:"objectId,omitempty"`
+	Email           string            `json:"email,omitempty"`
+	UTK             string            `json:"utk,omitempty"`
+	Domain          string            `json:"domain,omitempty"`
+	Tokens          map[string]string `json:"tokens,omitempty"`
+	// ExtraData is additional data shown in the details of the event, sent as it is.
+	ExtraData interface{} `json:"extraData,omitempty"`
+	// Timestamp is the time of the event. If nil, the time HubSpot receives it is used.
+	Timestamp  *HsTime `json:"timestamp,omitempty"`
+	ObjectType string  `json:"objectType,omitempty"`
+	CreatedAt  *HsTime `json:"createdAt,omitempty"`
+}
+
+// TimelineEventTemplate is the template of timeline events of an app.
+// HeaderTemplate and DetailTemplate are Markdown with Handlebars, in which the tokens are available.
+type TimelineEventTemplate struct {
+	ID             string                       `json:"id,omitempty"`
+	Name           string                       `json:"name"`
+	ObjectType     ObjectType                   `json:"objectType"`
+	HeaderTemplate string                       `json:"headerTemplate,omitempty"`
+	DetailTemplate string                       `json:"detailTemplate,omitempty"`
+	Tokens         []TimelineEventTemplateToken `json:"tokens,omitempty"`
+	CreatedAt      *HsTime                      `json:"createdAt,omitempty"`
+	UpdatedAt      *HsTime                      `json:"updatedAt,omitempty"`
+}
+
+// TimelineEventTemplateToken is a token of an event template.
+// Type is either "string", "number", "date" or "enumeration", and Options is required for the last one.
+// If ObjectPropertyName is set, the property of the object is updated with the value of the token.
+type TimelineEventTemplateToken struct {
+	Name               string           `json:"name"`
+	Label              string           `json:"label"`
+	Type               string           `json:"type"`
+	Options            []PropertyOption `json:"options,omitempty"`
+	ObjectPropertyName string           `json:"objectPropertyName,omitempty"`
+}
+
+type timelineEventTemplateList struct {
+	Results []*TimelineEventTemplate `json:"results"`
+}
+
+// CreateEvent creates an event on the timeline of an object from a registered event template.
+func (s *TimelineServiceOp) CreateEvent(event *TimelineEvent) (*TimelineEvent, error) {
+	resource := &TimelineEvent{}
+	if err := s.client.Post(s.timelinePath+"/events", event, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// CreateTemplate registers an event template of the app of appID.
+// NOTE: HubSpot only accepts the event template endpoints authenticated with the developer API key of the app.
+func (s *TimelineServiceOp) CreateTemplate(appID string, template *TimelineEventTemplate) (*TimelineEventTemplate, error) {
+	resource := &TimelineEventTemplate{}
+	if err := s.client.Post(s.templatePath(appID), template, resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// GetTemplates gets all event templates of the app of appID.
+func (s *TimelineServiceOp) GetTemplates(appID string) ([]*TimelineEventTemplate, error) {
+	resource := &timelineEventTemplateList{}
+	if err := s.client.Get(s.templatePath(appID), resource, nil); err != nil {
+		return nil, err
+	}
+	if resource.Results == nil {
+		resource.Results = []*TimelineEventTemplate{}
+	}
+	return resource.Results, nil
+}
+
+// DeleteTemplate deletes an event template of the app of appID.
+// The events created from the template are deleted as well.
+func (s *TimelineServiceOp) DeleteTemplate(appID, templateID string) error {
+	return s.client.Delete(s.templatePath(appID) + "/" + templateID)
+}
+
+func (s *TimelineServiceOp) templatePath(appID string) string {
+	return fmt.Sprintf("%s/%s/event-templates", s.timelinePath, appID)
+}
@@ -0,0 +1,77 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestTimelineServiceOp_CreateEvent(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusCreated,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"event001","eventTemplateId":"1001","objectId":"contact001","tokens":{"version":"1.2.3"},"timestamp":"2019-10-30T03:30:17.883Z","objectType":"contacts","createdAt":"2019-10-30T03:30:17.883Z"}`),
+	}
+	event := &hubspot.TimelineEvent{
+		EventTemplateID: "1001",
+		ObjectID:        "contact001",
+		Tokens:          map[string]string{"version": "1.2.3"},
+		Timestamp:       hubspot.NewTime(time.Date(2019, 10, 30, 3, 30, 17, 883000000, time.UTC)),
+	}
+	want := &hubspot.TimelineEvent{
+		ID:              "event001",
+		EventTemplateID: "1001",
+		ObjectID:        "contact001",
+		Tokens:          map[string]string{"version": "1.2.3"},
+		Timestamp:       &createdAt,
+		ObjectType:      "contacts",
+		CreatedAt:       &createdAt,
+	}
+	wantBody := `{"eventTemplateId":"1001","objectId":"contact001","tokens":{"version":"1.2.3"},"timestamp":"2019-10-30T03:30:17.883Z"}`
+
+	got, err := hubspot.NewMockClient(conf).CRM.Timeline.CreateEvent(event)
+	if err != nil {
+		t.Fatalf("CreateEvent() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("CreateEvent() response mismatch (-want +got):%s", diff)
+	}
+	req := conf.Requests[0]
+	if want := "/crm/v3/timeline/events"; req.Method != http.MethodPost || req.URL.Path != want {
+		t.Errorf("CreateEvent() request mismatch: want POST %s got %s %s", want, req.Method, req.URL.Path)
+	}
+	if string(req.Body) != wantBody {
+		t.Errorf("CreateEvent() request body mismatch: want %s got %s", wantBody, string(req.Body))
+	}
+}
+
+func TestTimelineServiceOp_GetTemplates(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"id":"1001","name":"Deployment","objectType":"contacts","headerTemplate":"Deployed version {{version}}","tokens":[{"name":"version","label":"Version","type":"string"}]}]}`),
+	}
+	want := []*hubspot.TimelineEventTemplate{
+		{
+			ID:             "1001",
+			Name:           "Deployment",
+			ObjectType:     hubspot.ObjectTypeContact,
+			HeaderTemplate: "Deployed version {{version}}",
+			Tokens:         []hubspot.TimelineEventTemplateToken{{Name: "version", Label: "Version", Type: "string"}},
+		},
+	}
+
+	got, err := hubspot.NewMockClient(conf).CRM.Timeline.GetTemplates("app001")
+	if err != nil {
+		t.Fatalf("GetTemplates() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpTimeOption); diff != "" {
+		t.Errorf("GetTemplates() response mismatch (-want +got):%s", diff)
+	}
+	if want := "/crm/v3/timeline/app001/event-templates"; conf.Requests[0].URL.Path != want {
+		t.Errorf("GetTemplates() request path mismatch: want %s got %s", want, conf.Requests[0].URL.Path)
+	}
+}
@@ -0,0 +1,59 @@
+package hubspot
+
+import (
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the name of the tracer creating the spans of the requests.
+	tracerName = "bendingspoons.com/hubspot"
+
+	// correlationIDHeader is the response header of the ID HubSpot assigns to each request for support.
+	correlationIDHeader = "X-HubSpot-Correlation-Id"
+)
+
+// Attribute keys of the spans of the requests.
+const (
+	attributeHTTPMethod           = attribute.Key("http.method")
+	attributeHTTPRoute            = attribute.Key("http.route")
+	attributeHTTPStatusCode       = attribute.Key("http.status_code")
+	attributeHubSpotCorrelationID = attribute.Key("hubspot.correlation_id")
+)
+
+// startSpan starts a span of the request as a child of the span in the context of the request,
+// and returns the request with the context of the new span.
+func (c *Client) startSpan(req *http.Request) (*http.Request, trace.Span) {
+	route := templatePath(req.URL.Path)
+	ctx, span := c.tracer.Start(req.Context(), "HubSpot "+req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributeHTTPMethod.String(req.Method), attributeHTTPRoute.String(route)),
+	)
+	return req.WithContext(ctx), span
+}
+
+// endSpan records the status and the correlation ID of the response, or the error, and ends the span.
+func endSpan(span trace.Span, resp *http.Response, err error) {
+	defer span.End()
+
+	correlationID := ""
+	if resp != nil {
+		span.SetAttributes(attributeHTTPStatusCode.Int(resp.StatusCode))
+		correlationID = resp.Header.Get(correlationIDHeader)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.CorrelationID != "" {
+		correlationID = apiErr.CorrelationID
+	}
+	if correlationID != "" {
+		span.SetAttributes(attributeHubSpotCorrelationID.String(correlationID))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,114 @@
+package hubspot_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"bendingspoons.com/hubspot"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusNotFound,
+		Header: http.Header{},
+		Body:   []byte(`{"status":"error","message":"Object not found.","correlationId":"5a479d9a-d0b9-4e0f-bcd7-f3fb878b83a6","category":"OBJECT_NOT_FOUND"}`),
+	}
+	tp := &recordingTracerProvider{}
+	c := hubspot.NewMockClient(conf)
+	if err := hubspot.WithTracerProvider(tp)(c); err != nil {
+		t.Fatalf("WithTracerProvider() unexpected error: %s", err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_ = c.CreateAndDoWithContext(ctx, http.MethodGet, "crm/v3/objects/companies/512", nil, nil, nil)
+	parent.End()
+
+	spans := tp.ended
+	if len(spans) != 2 {
+		t.Fatalf("spans mismatch: want 2 got %d", len(spans))
+	}
+	span := spans[0]
+	if want := "HubSpot GET /crm/v3/objects/companies/{id}"; span.name != want {
+		t.Errorf("span name mismatch: want %s got %s", want, span.name)
+	}
+	if span.parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("span parent mismatch: want the span of the context")
+	}
+	if span.status != codes.Error {
+		t.Errorf("span status mismatch: want Error got %s", span.status)
+	}
+	want := map[attribute.Key]attribute.Value{
+		"http.method":            attribute.StringValue(http.MethodGet),
+		"http.route":             attribute.StringValue("/crm/v3/objects/companies/{id}"),
+		"http.status_code":       attribute.IntValue(http.StatusNotFound),
+		"hubspot.correlation_id": attribute.StringValue("5a479d9a-d0b9-4e0f-bcd7-f3fb878b83a6"),
+	}
+	got := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.attributes {
+		got[kv.Key] = kv.Value
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("span attribute %s mismatch: want %s got %s", k, v.Emit(), got[k].Emit())
+		}
+	}
+}
+
+// recordingTracerProvider is a TracerProvider recording the spans in the order they are ended.
+type recordingTracerProvider struct {
+	ended  []*recordedSpan
+	nextID byte
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p}
+}
+
+type recordingTracer struct {
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	t.provider.nextID++
+	span := &recordedSpan{
+		Span:       trace.SpanFromContext(context.Background()),
+		provider:   t.provider,
+		name:       name,
+		parent:     trace.SpanContextFromContext(ctx),
+		attributes: cfg.Attributes(),
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{t.provider.nextID},
+		}),
+	}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// recordedSpan is a span recording its name, parent, attributes and status, the other methods are no-ops.
+type recordedSpan struct {
+	trace.Span
+	provider    *recordingTracerProvider
+	name        string
+	parent      trace.SpanContext
+	spanContext trace.SpanContext
+	attributes  []attribute.KeyValue
+	status      codes.Code
+}
+
+func (s *recordedSpan) SpanContext() trace.SpanContext { return s.spanContext }
+func (s *recordedSpan) IsRecording() bool              { return true }
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.provider.ended = append(s.provider.ended, s)
+}
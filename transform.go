@@ -0,0 +1,125 @@
+package hubspot
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// PropertyTransformer transforms the values of properties between the client and HubSpot,
+// e.g. to encrypt or pseudonymize personal data before it is stored in HubSpot.
+// Encode is applied to the values sent to HubSpot and Decode to the values received from it.
+// name is the name of the property in HubSpot.
+type PropertyTransformer interface {
+	Encode(name, value string) string
+	Decode(name, value string) string
+}
+
+// propertyTransform applies a PropertyTransformer to the properties of the given names.
+type propertyTransform struct {
+	transformer PropertyTransformer
+	names       map[string]bool
+}
+
+func newPropertyTransform(t PropertyTransformer, names []string) *propertyTransform {
+	pt := &propertyTransform{transformer: t, names: make(map[string]bool, len(names))}
+	for _, name := range names {
+		pt.names[name] = true
+	}
+	return pt
+}
+
+// encode encodes the properties of the JSON request body.
+func (pt *propertyTransform) encode(body []byte) ([]byte, error) {
+	return pt.apply(body, pt.transformer.Encode)
+}
+
+// decode decodes the properties of the JSON response body.
+func (pt *propertyTransform) decode(r io.Reader) (io.Reader, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err = pt.apply(body, pt.transformer.Decode)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(body), nil
+}
+
+// apply rewrites the string values of the transformed properties in every "properties" and "propertiesWithHistory"
+// object of the JSON, such as those of a request payload, a batch input, or the results of a response.
+// The JSON is returned as it is if no value is transformed.
+func (pt *propertyTransform) apply(body []byte, fn func(name, value string) string) ([]byte, error) {
+	if len(body) == 0 {
+		return body, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if !pt.walk(v, fn) {
+		return body, nil
+	}
+	return json.Marshal(v)
+}
+
+// walk transforms the properties found in v, and reports whether any value is transformed.
+func (pt *propertyTransform) walk(v interface{}, fn func(name, value string) string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			switch key {
+			case "properties":
+				if properties, ok := child.(map[string]interface{}); ok {
+					changed = pt.transform(properties, fn) || changed
+					continue
+				}
+			case "propertiesWithHistory":
+				if histories, ok := child.(map[string]interface{}); ok {
+					changed = pt.transformHistories(histories, fn) || changed
+					continue
+				}
+			}
+			changed = pt.walk(child, fn) || changed
+		}
+	case []interface{}:
+		for _, child := range v {
+			changed = pt.walk(child, fn) || changed
+		}
+	}
+	return changed
+}
+
+func (pt *propertyTransform) transform(properties map[string]interface{}, fn func(name, value string) string) bool {
+	changed := false
+	for name, value := range properties {
+		if s, ok := value.(string); ok && pt.names[name] {
+			properties[name] = fn(name, s)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (pt *propertyTransform) transformHistories(histories map[string]interface{}, fn func(name, value string) string) bool {
+	changed := false
+	for name, history := range histories {
+		entries, ok := history.([]interface{})
+		if !ok || !pt.names[name] {
+			continue
+		}
+		for _, entry := range entries {
+			if e, ok := entry.(map[string]interface{}); ok {
+				if s, ok := e["value"].(string); ok {
+					e["value"] = fn(name, s)
+					changed = true
+				}
+			}
+		}
+	}
+	return changed
+}
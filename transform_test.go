@@ -0,0 +1,67 @@
+package hubspot_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+type prefixTransformer struct{}
+
+func (prefixTransformer) Encode(name, value string) string { return "enc:" + value }
+
+func (prefixTransformer) Decode(name, value string) string { return strings.TrimPrefix(value, "enc:") }
+
+type piiCompany struct {
+	hubspot.Company
+	TaxID *hubspot.HsStr `json:"tax_id,omitempty"`
+}
+
+func TestWithPropertyTransformer(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"id":"company001","properties":{"name":"Acme","tax_id":"enc:123"},"propertiesWithHistory":{"tax_id":[{"value":"enc:123","timestamp":"2019-10-30T03:30:17.883Z","sourceType":"API"}]}}`),
+	}
+	c := hubspot.NewMockClient(conf)
+	if err := hubspot.WithPropertyTransformer(prefixTransformer{}, "tax_id")(c); err != nil {
+		t.Fatalf("WithPropertyTransformer() unexpected error: %s", err)
+	}
+
+	got, err := c.CRM.Company.Create(&piiCompany{Company: hubspot.Company{Name: hubspot.NewString("Acme")}, TaxID: hubspot.NewString("123")})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %s", err)
+	}
+	if want := `{"properties":{"name":"Acme","tax_id":"enc:123"}}`; string(conf.Requests[0].Body) != want {
+		t.Errorf("Create() request body mismatch: want %s got %s", want, string(conf.Requests[0].Body))
+	}
+	want := &piiCompany{Company: hubspot.Company{Name: hubspot.NewString("Acme")}, TaxID: hubspot.NewString("123")}
+	if diff := cmp.Diff(want, got.Properties); diff != "" {
+		t.Errorf("Create() response mismatch (-want +got):%s", diff)
+	}
+	if history := got.PropertiesWithHistory["tax_id"]; len(history) != 1 || history[0].Value != "123" {
+		t.Errorf("Create() history mismatch: want the decoded value got %v", history)
+	}
+}
+
+func TestWithPropertyTransformer_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		transformer hubspot.PropertyTransformer
+		names       []string
+	}{
+		{name: "Nil transformer", transformer: nil, names: []string{"tax_id"}},
+		{name: "No property names", transformer: prefixTransformer{}, names: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := hubspot.NewMockClient(&hubspot.MockConfig{})
+			if err := hubspot.WithPropertyTransformer(tt.transformer, tt.names...)(c); err == nil {
+				t.Error("WithPropertyTransformer() error mismatch: want error got nil")
+			}
+		})
+	}
+}
@@ -2,24 +2,52 @@ package hubspot
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // HsStr is defined to identify HubSpot's empty string from null.
-// If you want to set a HubSpot's value, use NewString(), if null, use `nil` in the request field.
+// A *HsStr field of a request has three states:
+//   - nil: the property is unset, and the key is omitted by omitempty, so HubSpot keeps the current value.
+//   - NewString("value"): the property is set to the value.
+//   - ClearString(): the property is sent as "", which HubSpot interprets as clearing the value.
 type HsStr string
 
 // BlankStr should be used to include empty string in HubSpot fields.
 // This is because fields set to `nil` will be ignored by omitempty.
+// Prefer ClearString(), since BlankStr is shared and must not be modified.
 var BlankStr = NewString("")
 
 // NewString returns pointer HsStr(string).
-// Make sure to use BlankStr for empty string.
+// Note that NewString("") clears the property in the same way as ClearString(), use nil to leave it unset.
 func NewString(s string) *HsStr {
 	v := HsStr(s)
 	return &v
 }
 
+// ClearString returns a value that clears the property when sent to HubSpot.
+// HubSpot clears a property when a create or an update sends it an empty string,
+// so it is marshaled as "", while a nil *HsStr is omitted and leaves the property unchanged.
+// It is the same as NewString(""), and only names the intent.
+func ClearString() *HsStr {
+	return NewString("")
+}
+
+// IsClear reports whether the value clears the property, i.e. it is set and empty.
+// A nil value is unset and does not clear the property.
+func (hs *HsStr) IsClear() bool {
+	return hs != nil && *hs == ""
+}
+
+// IsEmpty reports whether the value is nil or empty, i.e. the property has no value.
+// Unlike IsClear, it does not distinguish an unset value from a cleared one.
+func (hs *HsStr) IsEmpty() bool {
+	return hs == nil || *hs == ""
+}
+
 // String implemented Stringer.
 func (hs *HsStr) String() string {
 	if hs == nil {
@@ -28,9 +56,104 @@ func (hs *HsStr) String() string {
 	return string(*hs)
 }
 
+// UnmarshalJSON implemented json.Unmarshaler.
+// HubSpot returns properties as strings, but a number, such as a numeric ID, is also accepted and kept as its literal,
+// so that IDs larger than 2^53 do not lose precision as they would through float64.
+// A null value leaves the value unchanged.
+func (hs *HsStr) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if len(b) > 0 && b[0] != '"' {
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return err
+		}
+		*hs = HsStr(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*hs = HsStr(s)
+	return nil
+}
+
+// multiValueSeparator is the separator HubSpot uses for multi-value properties such as multiple checkboxes.
+const multiValueSeparator = ";"
+
+// Values returns the values of a semicolon-separated multi-value property.
+// Empty values are omitted, and nil is returned if there is no value.
+func (hs *HsStr) Values() []string {
+	var values []string
+	for _, v := range strings.Split(hs.String(), multiValueSeparator) {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// AddToSet returns a multi-value property with the value appended.
+// If the value is empty or already exists, the property is returned unchanged.
+// e.g. company.ProductNames = company.ProductNames.AddToSet("product")
+func (hs *HsStr) AddToSet(value string) *HsStr {
+	if value == "" {
+		return hs
+	}
+	values := hs.Values()
+	for _, v := range values {
+		if v == value {
+			return hs
+		}
+	}
+	return NewString(strings.Join(append(values, value), multiValueSeparator))
+}
+
+// RemoveFromSet returns a multi-value property with all occurrences of the value removed.
+// The order of the remaining values is preserved. If there is no remaining value, ClearString() is returned,
+// so that an update clears the property in HubSpot instead of omitting it. nil is returned only for a nil receiver.
+// e.g. company.ProductNames = company.ProductNames.RemoveFromSet("product")
+func (hs *HsStr) RemoveFromSet(value string) *HsStr {
+	if hs == nil {
+		return nil
+	}
+	values := []string{}
+	for _, v := range hs.Values() {
+		if v != value {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return ClearString()
+	}
+	return NewString(strings.Join(values, multiValueSeparator))
+}
+
+// Bool parses a boolean property such as a single checkbox, which HubSpot returns as "true" or "false".
+// The second return value reports whether the value is a valid boolean, it is false for nil and empty values.
+func (hs *HsStr) Bool() (bool, bool) {
+	switch hs.String() {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
 // HsBool is defined to marshal the HubSpot boolean fields of `true`, `"true"`, and so on, into a bool type.
+// It is marshaled to the string form "true" or "false" that HubSpot uses for boolean properties.
 type HsBool bool
 
+// NewBool returns pointer HsBool(bool).
+func NewBool(b bool) *HsBool {
+	v := HsBool(b)
+	return &v
+}
+
 // UnmarshalJSON implemented json.Unmarshaler.
 // This is because there are cases where the Time value returned by HubSpot is null or "true" / "false".
 func (hb *HsBool) UnmarshalJSON(b []byte) error {
@@ -43,6 +166,112 @@ func (hb *HsBool) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON implemented json.Marshaler.
+// HubSpot stores boolean properties as the strings "true" and "false".
+func (hb HsBool) MarshalJSON() ([]byte, error) {
+	if hb {
+		return []byte(`"true"`), nil
+	}
+	return []byte(`"false"`), nil
+}
+
+// HsInt is defined to bind HubSpot number properties holding integers.
+// HubSpot returns numbers either quoted or unquoted, so both are accepted.
+type HsInt int64
+
+// NewInt returns pointer HsInt(int64).
+func NewInt(i int64) *HsInt {
+	v := HsInt(i)
+	return &v
+}
+
+// Int64 returns the value as int64, or 0 if it is nil.
+func (hi *HsInt) Int64() int64 {
+	if hi == nil {
+		return 0
+	}
+	return int64(*hi)
+}
+
+// UnmarshalJSON implemented json.Unmarshaler.
+// This is because HubSpot returns numbers as strings such as "10", and sometimes with a fraction such as "10.0".
+// A null or empty string value leaves the value unchanged.
+func (hi *HsInt) UnmarshalJSON(b []byte) error {
+	s, ok := unquoteNumber(b)
+	if !ok {
+		return nil
+	}
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*hi = HsInt(v)
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) {
+		return fmt.Errorf("invalid integer value: %s", string(b))
+	}
+	*hi = HsInt(f)
+	return nil
+}
+
+// MarshalJSON implemented json.Marshaler.
+func (hi HsInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(hi), 10)), nil
+}
+
+// HsFloat is defined to bind HubSpot number properties such as amounts.
+// HubSpot returns numbers either quoted or unquoted, so both are accepted.
+type HsFloat float64
+
+// NewFloat returns pointer HsFloat(float64).
+func NewFloat(f float64) *HsFloat {
+	v := HsFloat(f)
+	return &v
+}
+
+// Float64 returns the value as float64, or 0 if it is nil.
+func (hf *HsFloat) Float64() float64 {
+	if hf == nil {
+		return 0
+	}
+	return float64(*hf)
+}
+
+// UnmarshalJSON implemented json.Unmarshaler.
+// This is because HubSpot returns numbers as strings such as "1500.00".
+// A null or empty string value leaves the value unchanged.
+func (hf *HsFloat) UnmarshalJSON(b []byte) error {
+	s, ok := unquoteNumber(b)
+	if !ok {
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number value: %s", string(b))
+	}
+	*hf = HsFloat(f)
+	return nil
+}
+
+// MarshalJSON implemented json.Marshaler.
+// NaN and infinities have no JSON representation, so an error is returned for them.
+func (hf HsFloat) MarshalJSON() ([]byte, error) {
+	if f := float64(hf); math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("invalid number value: %v is not supported by HubSpot", f)
+	}
+	return []byte(strconv.FormatFloat(float64(hf), 'f', -1, 64)), nil
+}
+
+// unquoteNumber returns the number of a quoted or unquoted JSON value.
+// It returns false for null and empty string.
+func unquoteNumber(b []byte) (string, bool) {
+	s := string(b)
+	if s == "null" {
+		return "", false
+	}
+	s = strings.TrimSpace(strings.Trim(s, `"`))
+	return s, s != ""
+}
+
 // HsTime is defined to identify HubSpot time fields with null and empty string.
 // If you want to set a HubSpot's value, use NewTime(), if null, use `nil` in the request field.
 type HsTime time.Time
@@ -69,6 +298,12 @@ func (ht *HsTime) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON implemented json.Marshaler.
+// HsTime is marshaled in the same format as time.Time, which HubSpot accepts for date and datetime properties.
+func (ht HsTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(ht))
+}
+
 // String implemented Stringer.
 func (ht *HsTime) String() string {
 	if ht == nil {
@@ -81,6 +316,19 @@ func (ht *HsTime) String() string {
 	return v.String()
 }
 
+// Time returns the value as time.Time, or the zero time if it is nil.
+func (ht *HsTime) Time() time.Time {
+	if ht == nil {
+		return time.Time{}
+	}
+	return time.Time(*ht)
+}
+
+// IsZero reports whether the value is nil or the zero time, i.e. the property has no value.
+func (ht *HsTime) IsZero() bool {
+	return ht.Time().IsZero()
+}
+
 // ToTime convert HsTime to time.Time.
 // If the value is zero, it will be return nil.
 func (ht *HsTime) ToTime() *time.Time {
@@ -1,6 +1,8 @@
 package hubspot_test
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
@@ -103,3 +105,483 @@ func TestHsTime_ToTime(t *testing.T) {
 		})
 	}
 }
+
+func TestHsStr_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		hs   *hubspot.HsStr
+		want bool
+	}{
+		{name: "Value", hs: hubspot.NewString("text"), want: false},
+		{name: "Nil receiver", hs: nil, want: true},
+		{name: "Empty string", hs: hubspot.ClearString(), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hs.IsEmpty(); got != tt.want {
+				t.Errorf("HsStr.IsEmpty() mismatch: want %v, got = %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHsTime_Time(t *testing.T) {
+	tests := []struct {
+		name       string
+		ht         *hubspot.HsTime
+		want       time.Time
+		wantIsZero bool
+	}{
+		{name: "Value", ht: hubspot.NewTime(testDate), want: testDate, wantIsZero: false},
+		{name: "Nil receiver", ht: nil, want: time.Time{}, wantIsZero: true},
+		{name: "Zero value", ht: &hubspot.HsTime{}, want: time.Time{}, wantIsZero: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ht.Time(); !got.Equal(tt.want) {
+				t.Errorf("HsTime.Time() mismatch: want %v, got = %v", tt.want, got)
+			}
+			if got := tt.ht.IsZero(); got != tt.wantIsZero {
+				t.Errorf("HsTime.IsZero() mismatch: want %v, got = %v", tt.wantIsZero, got)
+			}
+		})
+	}
+}
+
+func TestHsStr_Values(t *testing.T) {
+	tests := []struct {
+		name string
+		hs   *hubspot.HsStr
+		want []string
+	}{
+		{
+			name: "Success case of nil receiver",
+			hs:   nil,
+			want: nil,
+		},
+		{
+			name: "Success case of empty string",
+			hs:   hubspot.NewString(""),
+			want: nil,
+		},
+		{
+			name: "Success with single value",
+			hs:   hubspot.NewString("a"),
+			want: []string{"a"},
+		},
+		{
+			name: "Success with many values",
+			hs:   hubspot.NewString("a;b;;c"),
+			want: []string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.hs.Values()); diff != "" {
+				t.Errorf("HsStr.Values() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestHsStr_AddToSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		hs    *hubspot.HsStr
+		value string
+		want  *hubspot.HsStr
+	}{
+		{
+			name:  "Success case of nil receiver",
+			hs:    nil,
+			value: "a",
+			want:  hubspot.NewString("a"),
+		},
+		{
+			name:  "Success case of empty string",
+			hs:    hubspot.NewString(""),
+			value: "a",
+			want:  hubspot.NewString("a"),
+		},
+		{
+			name:  "Success with single value",
+			hs:    hubspot.NewString("a"),
+			value: "b",
+			want:  hubspot.NewString("a;b"),
+		},
+		{
+			name:  "Success with many values",
+			hs:    hubspot.NewString("a;b;c"),
+			value: "d",
+			want:  hubspot.NewString("a;b;c;d"),
+		},
+		{
+			name:  "Success with existing value",
+			hs:    hubspot.NewString("a;b;c"),
+			value: "b",
+			want:  hubspot.NewString("a;b;c"),
+		},
+		{
+			name:  "Success with empty value",
+			hs:    hubspot.NewString("a"),
+			value: "",
+			want:  hubspot.NewString("a"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.hs.AddToSet(tt.value)); diff != "" {
+				t.Errorf("HsStr.AddToSet() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestHsStr_RemoveFromSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		hs    *hubspot.HsStr
+		value string
+		want  *hubspot.HsStr
+	}{
+		{
+			name:  "Success case of nil receiver",
+			hs:    nil,
+			value: "a",
+			want:  nil,
+		},
+		{
+			name:  "Success case of empty string",
+			hs:    hubspot.NewString(""),
+			value: "a",
+			want:  hubspot.ClearString(),
+		},
+		{
+			name:  "Success with single value",
+			hs:    hubspot.NewString("a"),
+			value: "a",
+			want:  hubspot.ClearString(),
+		},
+		{
+			name:  "Success with many values",
+			hs:    hubspot.NewString("a;b;c;b"),
+			value: "b",
+			want:  hubspot.NewString("a;c"),
+		},
+		{
+			name:  "Success with missing value",
+			hs:    hubspot.NewString("a;b"),
+			value: "c",
+			want:  hubspot.NewString("a;b"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.hs.RemoveFromSet(tt.value)); diff != "" {
+				t.Errorf("HsStr.RemoveFromSet() mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestHsTime_MarshalJSON(t *testing.T) {
+	type payload struct {
+		Time *hubspot.HsTime `json:"time,omitempty"`
+	}
+	tests := []struct {
+		name string
+		in   payload
+		want string
+	}{
+		{
+			name: "Success",
+			in:   payload{Time: hubspot.NewTime(testDate)},
+			want: `{"time":"2022-02-28T00:00:00Z"}`,
+		},
+		{
+			name: "Success case of nil",
+			in:   payload{},
+			want: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("HsTime.MarshalJSON() error: %s", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("HsTime.MarshalJSON() mismatch: want %s, got = %s", tt.want, string(b))
+			}
+		})
+	}
+}
+
+func TestHsStr_MarshalJSON(t *testing.T) {
+	type payload struct {
+		Name *hubspot.HsStr `json:"name,omitempty"`
+	}
+	tests := []struct {
+		name      string
+		hs        *hubspot.HsStr
+		want      string
+		wantClear bool
+	}{
+		{
+			name: "Unset omits the key",
+			hs:   nil,
+			want: `{}`,
+		},
+		{
+			name:      "Clear sends an empty string",
+			hs:        hubspot.ClearString(),
+			want:      `{"name":""}`,
+			wantClear: true,
+		},
+		{
+			name: "Value sends the value",
+			hs:   hubspot.NewString("Acme"),
+			want: `{"name":"Acme"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(&payload{Name: tt.hs})
+			if err != nil {
+				t.Fatalf("json.Marshal() error: %s", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("json.Marshal() mismatch: want %s got %s", tt.want, string(b))
+			}
+			if got := tt.hs.IsClear(); got != tt.wantClear {
+				t.Errorf("IsClear() mismatch: want %v got %v", tt.wantClear, got)
+			}
+
+			got := &payload{}
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("json.Unmarshal() error: %s", err)
+			}
+			if diff := cmp.Diff(tt.hs, got.Name); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestHsBool_JSON(t *testing.T) {
+	type payload struct {
+		Enabled *hubspot.HsBool `json:"enabled,omitempty"`
+	}
+	tests := []struct {
+		name     string
+		hb       *hubspot.HsBool
+		wantJSON string
+	}{
+		{
+			name:     "True is sent as a string",
+			hb:       hubspot.NewBool(true),
+			wantJSON: `{"enabled":"true"}`,
+		},
+		{
+			name:     "False is sent as a string",
+			hb:       hubspot.NewBool(false),
+			wantJSON: `{"enabled":"false"}`,
+		},
+		{
+			name:     "Nil is omitted",
+			hb:       nil,
+			wantJSON: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(&payload{Enabled: tt.hb})
+			if err != nil {
+				t.Fatalf("json.Marshal() error: %s", err)
+			}
+			if string(b) != tt.wantJSON {
+				t.Errorf("json.Marshal() mismatch: want %s got %s", tt.wantJSON, string(b))
+			}
+
+			got := &payload{}
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("json.Unmarshal() error: %s", err)
+			}
+			if diff := cmp.Diff(tt.hb, got.Enabled); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):%s", diff)
+			}
+		})
+	}
+}
+
+func TestHsStr_Bool(t *testing.T) {
+	tests := []struct {
+		name   string
+		hs     *hubspot.HsStr
+		want   bool
+		wantOK bool
+	}{
+		{
+			name:   "True",
+			hs:     hubspot.NewString("true"),
+			want:   true,
+			wantOK: true,
+		},
+		{
+			name:   "False",
+			hs:     hubspot.NewString("false"),
+			want:   false,
+			wantOK: true,
+		},
+		{
+			name: "Empty",
+			hs:   hubspot.NewString(""),
+		},
+		{
+			name: "Nil",
+			hs:   nil,
+		},
+		{
+			name: "Not a boolean",
+			hs:   hubspot.NewString("yes"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.hs.Bool()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Bool() mismatch: want (%v, %v) got (%v, %v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestHsInt_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int64
+		wantErr bool
+	}{
+		{name: "Unquoted", data: `10`, want: 10},
+		{name: "Quoted", data: `"10"`, want: 10},
+		{name: "Quoted with a zero fraction", data: `"10.0"`, want: 10},
+		{name: "Empty string", data: `""`, want: 0},
+		{name: "Null", data: `null`, want: 0},
+		{name: "Fraction", data: `"10.5"`, wantErr: true},
+		{name: "Not a number", data: `"ten"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hubspot.HsInt
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error mismatch: wantErr %v got %v", tt.wantErr, err)
+			}
+			if got.Int64() != tt.want {
+				t.Errorf("UnmarshalJSON() mismatch: want %d got %d", tt.want, got.Int64())
+			}
+		})
+	}
+}
+
+func TestHsStr_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Quoted", data: `"9007199254740993"`, want: "9007199254740993"},
+		{name: "Unquoted ID beyond float64 precision", data: `9007199254740993`, want: "9007199254740993"},
+		{name: "Empty string", data: `""`, want: ""},
+		{name: "Null", data: `null`, want: ""},
+		{name: "Not a string or number", data: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hubspot.HsStr
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error mismatch: wantErr %v got %v", tt.wantErr, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("UnmarshalJSON() mismatch: want %s got %s", tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestHsFloat_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "Unquoted", data: `1500.5`, want: 1500.5},
+		{name: "Quoted", data: `"1500.00"`, want: 1500},
+		{name: "Empty string", data: `""`, want: 0},
+		{name: "Null", data: `null`, want: 0},
+		{name: "Not a number", data: `"abc"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hubspot.HsFloat
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error mismatch: wantErr %v got %v", tt.wantErr, err)
+			}
+			if got.Float64() != tt.want {
+				t.Errorf("UnmarshalJSON() mismatch: want %v got %v", tt.want, got.Float64())
+			}
+		})
+	}
+}
+
+func TestHsNumber_MarshalJSON(t *testing.T) {
+	type payload struct {
+		Count  *hubspot.HsInt   `json:"count,omitempty"`
+		Amount *hubspot.HsFloat `json:"amount,omitempty"`
+	}
+	tests := []struct {
+		name    string
+		payload *payload
+		want    string
+	}{
+		{
+			name:    "Set values",
+			payload: &payload{Count: hubspot.NewInt(3), Amount: hubspot.NewFloat(1500.5)},
+			want:    `{"count":3,"amount":1500.5}`,
+		},
+		{
+			name:    "Nil values are omitted",
+			payload: &payload{},
+			want:    `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.payload)
+			if err != nil {
+				t.Fatalf("json.Marshal() error: %s", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("json.Marshal() mismatch: want %s got %s", tt.want, string(b))
+			}
+		})
+	}
+	var nilInt *hubspot.HsInt
+	var nilFloat *hubspot.HsFloat
+	if nilInt.Int64() != 0 || nilFloat.Float64() != 0 {
+		t.Error("nil accessors mismatch: want 0")
+	}
+}
+
+func TestHsFloat_MarshalJSON_NotFinite(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := json.Marshal(hubspot.NewFloat(f)); err == nil {
+			t.Errorf("json.Marshal() error mismatch: want error for %v got nil", f)
+		}
+	}
+}
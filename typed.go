@@ -0,0 +1,62 @@
+//go:build go1.18
+// +build go1.18
+
+package hubspot
+
+import "encoding/json"
+
+// SearchTyped searches the companies in the same way as CompanyService.Search, and returns the properties of each result
+// decoded into T, e.g. hubspot.SearchTyped[hubspot.Company](client.CRM.Company, option).
+// T is a properties structure such as Company, or a structure embedding it for the custom properties.
+// The paging of the results is returned to get the next page with RequestSearchOption.After.
+func SearchTyped[T any](s CompanyService, option *RequestSearchOption) ([]T, *Paging, error) {
+	resource, err := s.Search(new(T), option)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := typedProperties[T](resource.Results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, resource.Paging, nil
+}
+
+// GetAllTyped gets a page of companies in the same way as CompanyService.GetAll, and returns the properties of each
+// result decoded into T, e.g. hubspot.GetAllTyped[hubspot.Company](client.CRM.Company, nil).
+// The paging of the results is returned to get the next page with RequestQueryOption.After.
+func GetAllTyped[T any](s CompanyService, option *RequestQueryOption) ([]T, *Paging, error) {
+	resource, err := s.GetAll(new(T), option)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := typedProperties[T](resource.Results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, resource.Paging, nil
+}
+
+// typedProperties returns the properties of the results as T.
+// The properties already bound to *T are used as they are, and the others, such as maps, are decoded into T
+// in the same way as a response.
+func typedProperties[T any](results []ResponseResource) ([]T, error) {
+	items := make([]T, 0, len(results))
+	for _, result := range results {
+		if p, ok := result.Properties.(*T); ok && p != nil {
+			items = append(items, *p)
+			continue
+		}
+		item := new(T)
+		if result.Properties != nil {
+			b, err := json.Marshal(result.Properties)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(b, withPropertyMapping(item)); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, *item)
+	}
+	return items, nil
+}
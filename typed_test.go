@@ -0,0 +1,60 @@
+//go:build go1.18
+// +build go1.18
+
+package hubspot_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"bendingspoons.com/hubspot"
+)
+
+func TestSearchTyped(t *testing.T) {
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"total":3,"results":[{"id":"company001","properties":{"name":"Acme"}},{"id":"company002","properties":{"name":"Globex"}}],"paging":{"next":{"after":"2"}}}`),
+	}
+	want := []hubspot.Company{{Name: hubspot.NewString("Acme")}, {Name: hubspot.NewString("Globex")}}
+
+	got, paging, err := hubspot.SearchTyped[hubspot.Company](hubspot.NewMockClient(conf).CRM.Company, &hubspot.RequestSearchOption{})
+	if err != nil {
+		t.Fatalf("SearchTyped() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SearchTyped() response mismatch (-want +got):%s", diff)
+	}
+	if diff := cmp.Diff(&hubspot.Paging{Next: &hubspot.PagingNext{After: "2"}}, paging); diff != "" {
+		t.Errorf("SearchTyped() paging mismatch (-want +got):%s", diff)
+	}
+}
+
+func TestGetAllTyped(t *testing.T) {
+	type CustomCompany struct {
+		hubspot.Company
+		ExternalID *hubspot.HsStr `hubspot:"external_id"`
+	}
+
+	conf := &hubspot.MockConfig{
+		Status: http.StatusOK,
+		Header: http.Header{},
+		Body:   []byte(`{"results":[{"id":"company001","properties":{"name":"Acme","external_id":"ext-1"}},{"id":"company002"}]}`),
+	}
+	want := []CustomCompany{
+		{Company: hubspot.Company{Name: hubspot.NewString("Acme")}, ExternalID: hubspot.NewString("ext-1")},
+		{},
+	}
+
+	got, paging, err := hubspot.GetAllTyped[CustomCompany](hubspot.NewMockClient(conf).CRM.Company, nil)
+	if err != nil {
+		t.Fatalf("GetAllTyped() unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetAllTyped() response mismatch (-want +got):%s", diff)
+	}
+	if paging != nil {
+		t.Errorf("GetAllTyped() paging mismatch: want nil got %+v", paging)
+	}
+}